@@ -0,0 +1,105 @@
+package table
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Alignment describes how text is positioned within the width of its column.
+type Alignment int
+
+const (
+	// AlignLeft places text against the left edge of the column. It is the
+	// default alignment.
+	AlignLeft Alignment = iota
+
+	// AlignRight places text against the right edge of the column, before
+	// the padding that separates it from the next column.
+	AlignRight
+
+	// AlignCenter places text in the middle of the column, with any odd
+	// space left over going to the right.
+	AlignCenter
+)
+
+// align pads s to the column width w according to a. The column's padding,
+// which is included in w, always stays to the right of the text.
+func (t *table) align(s string, w int, a Alignment) string {
+	width := t.Width(s)
+	gap := w - t.Padding - width
+
+	left := 0
+	switch {
+	case gap <= 0:
+	case a == AlignRight:
+		left = gap
+	case a == AlignCenter:
+		left = gap / 2
+	}
+
+	right := w - left - width
+	if right < 0 {
+		right = 0
+	}
+
+	return strings.Repeat(" ", left) + s + strings.Repeat(" ", right)
+}
+
+// appendRow appends row to the table along with the alignments of its cells,
+// which may be nil. The caller must hold t.mu.
+func (t *table) appendRow(row []string, aligns []Alignment) {
+	if aligns != nil && t.cellAligns == nil {
+		t.cellAligns = make([][]Alignment, len(t.rows))
+	}
+
+	t.rows = append(t.rows, row)
+	if t.cellAligns != nil {
+		t.cellAligns = append(t.cellAligns, aligns)
+	}
+}
+
+// columnAlign returns the alignment set for the column at col with
+// WithColumnAlignment, or AlignLeft if there is none.
+func (t *table) columnAlign(col int) Alignment {
+	if col < len(t.columnAligns) {
+		return t.columnAligns[col]
+	}
+	return AlignLeft
+}
+
+// rowAligns returns the cell alignments of the row at index, or nil if none
+// were provided.
+func (t *table) rowAligns(index int) []Alignment {
+	if index >= len(t.cellAligns) {
+		return nil
+	}
+	return t.cellAligns[index]
+}
+
+// smartAligns returns aligns extended with the alignments chosen for the
+// cells of line in columns set up with WithSmartAlignment. Cells with an entry
+// in aligns keep it.
+func (t *table) smartAligns(line []string, aligns []Alignment) []Alignment {
+	if len(t.SmartAligns) == 0 {
+		return aligns
+	}
+
+	out := make([]Alignment, max(len(aligns), len(line)))
+	copy(out, aligns)
+	for i := len(aligns); i < len(out); i++ {
+		out[i] = t.columnAlign(i)
+	}
+	for col := range t.SmartAligns {
+		if col < len(aligns) || col >= len(line) {
+			continue
+		}
+		v := strings.TrimSpace(line[col])
+		if sep, ok := t.ThousandsSeparators[col]; ok {
+			v = strings.ReplaceAll(v, string(sep), "")
+		}
+		if _, err := strconv.ParseFloat(v, 64); err == nil {
+			out[col] = AlignRight
+		}
+	}
+	return out
+}
@@ -0,0 +1,48 @@
+package table
+
+import (
+	"strings"
+	"unicode"
+)
+
+// CaseMode describes how the letters of a cell are cased when printed. See
+// WithColumnCase.
+type CaseMode int
+
+const (
+	// CaseNone leaves cells as they are. It is the default.
+	CaseNone CaseMode = iota
+
+	// CaseUpper prints cells in upper case.
+	CaseUpper
+
+	// CaseLower prints cells in lower case.
+	CaseLower
+
+	// CaseTitle prints the first letter of each word in upper case and the
+	// rest in lower case. Words are separated by anything that is not a
+	// letter, digit or apostrophe.
+	CaseTitle
+)
+
+// apply returns s in the case described by c.
+func (c CaseMode) apply(s string) string {
+	switch c {
+	case CaseUpper:
+		return strings.ToUpper(s)
+	case CaseLower:
+		return strings.ToLower(s)
+	case CaseTitle:
+		inWord := false
+		return strings.Map(func(r rune) rune {
+			wasInWord := inWord
+			inWord = unicode.IsLetter(r) || unicode.IsDigit(r) || r == '\''
+			if wasInWord {
+				return unicode.ToLower(r)
+			}
+			return unicode.ToTitle(r)
+		}, s)
+	default:
+		return s
+	}
+}
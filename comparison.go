@@ -0,0 +1,235 @@
+package table
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// A ComparisonFunc compares two cell values, returning a negative number if a
+// sorts before b, a positive number if a sorts after b, and zero if they are
+// equivalent. The comparison functions provided by this package return -1, 0
+// or 1. They can be used with the sort package to order rows by a column:
+//
+//	sort.SliceStable(rows, func(i, j int) bool {
+//	  return table.LengthComparison(rows[i][1], rows[j][1]) < 0
+//	})
+type ComparisonFunc func(a, b string) int
+
+// LengthComparison compares a and b by their length in runes, so shorter values
+// sort first. Values of equal length are considered equivalent.
+func LengthComparison(a, b string) int {
+	return compareInts(utf8.RuneCountInString(a), utf8.RuneCountInString(b))
+}
+
+// CaseInsensitiveStringComparison compares a and b as strings, ignoring case,
+// so "apple" sorts before "Zebra". Values that differ only in case are ordered
+// by strings.Compare, so upper case sorts first and the order is stable.
+func CaseInsensitiveStringComparison(a, b string) int {
+	if c := strings.Compare(strings.ToLower(a), strings.ToLower(b)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// NaturalComparison compares a and b in natural order, so "file2" sorts
+// before "file10". Each value is split into runs of digits and runs of other
+// characters: runs of digits are compared as whole numbers, and other runs as
+// strings. Numbers that are equal except for leading zeros, such as "01" and
+// "1", are ordered with more zeros first when nothing else differs. Values
+// without digits are compared like strings.Compare.
+func NaturalComparison(a, b string) int {
+	tie := 0
+	for a != "" && b != "" {
+		x, y := naturalChunk(a), naturalChunk(b)
+		a, b = a[len(x):], b[len(y):]
+
+		if !isDigit(x[0]) || !isDigit(y[0]) {
+			if c := strings.Compare(x, y); c != 0 {
+				return c
+			}
+			continue
+		}
+
+		xn, yn := strings.TrimLeft(x, "0"), strings.TrimLeft(y, "0")
+		if c := compareInts(len(xn), len(yn)); c != 0 {
+			return c
+		}
+		if c := strings.Compare(xn, yn); c != 0 {
+			return c
+		}
+		if tie == 0 {
+			tie = compareInts(len(y), len(x))
+		}
+	}
+
+	switch {
+	case a != "":
+		return 1
+	case b != "":
+		return -1
+	default:
+		return tie
+	}
+}
+
+// naturalChunk returns the leading run of digits, or of other characters, of
+// the non-empty s.
+func naturalChunk(s string) string {
+	digits := isDigit(s[0])
+	for i := 1; i < len(s); i++ {
+		if isDigit(s[i]) != digits {
+			return s[:i]
+		}
+	}
+	return s
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// CollationComparison returns a ComparisonFunc that orders values according to
+// the collation rules of the language identified by tag, such as language.German
+// or language.Swedish, so that accented letters sort where readers of that
+// language expect them. The returned function is safe for concurrent use.
+//
+//	cmp := table.CollationComparison(language.Swedish)
+//	// cmp("ö", "z") == 1, since ö follows z in Swedish
+func CollationComparison(tag language.Tag) ComparisonFunc {
+	var (
+		mu sync.Mutex
+		c  = collate.New(tag)
+	)
+	return func(a, b string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return c.CompareString(a, b)
+	}
+}
+
+// RegexGroupComparison returns a ComparisonFunc that compares the text matched
+// by capture group group of re in each value using inner, such as to order log
+// lines by an embedded timestamp. Group 0 is the whole match. Values that re
+// does not match, or where the group did not participate in the match or does
+// not exist, sort after all matching values, and are compared as strings with
+// each other.
+//
+//	cmp := table.RegexGroupComparison(regexp.MustCompile(`size=(\S+)`), 1, table.FileSizeComparison)
+//	// cmp("a size=2K", "b size=512") == 1
+func RegexGroupComparison(re *regexp.Regexp, group int, inner ComparisonFunc) ComparisonFunc {
+	extract := func(s string) (string, bool) {
+		m := re.FindStringSubmatchIndex(s)
+		if group < 0 || 2*group+1 >= len(m) || m[2*group] < 0 {
+			return "", false
+		}
+		return s[m[2*group]:m[2*group+1]], true
+	}
+
+	return func(a, b string) int {
+		x, xOK := extract(a)
+		y, yOK := extract(b)
+
+		switch {
+		case xOK && yOK:
+			return inner(x, y)
+		case xOK:
+			return -1
+		case yOK:
+			return 1
+		default:
+			return strings.Compare(a, b)
+		}
+	}
+}
+
+// FileSizeComparison compares a and b as human-readable byte sizes, such as
+// "500", "1.5K", "2 MB" or "3GiB", so that smaller sizes sort first. Unit
+// prefixes are case-insensitive. A bare prefix (K, M, G, T) or one followed by
+// "iB" is binary, a multiple of 1024, as printed by ls -h; one followed by just
+// "B" is decimal, a multiple of 1000. Values that are not sizes sort after all
+// sizes, and are compared as strings with each other.
+func FileSizeComparison(a, b string) int {
+	x, xOK := parseFileSize(a)
+	y, yOK := parseFileSize(b)
+
+	switch {
+	case xOK && yOK:
+		return compareFloats(x, y)
+	case xOK:
+		return -1
+	case yOK:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// parseFileSize parses s as described by FileSizeComparison, returning its size
+// in bytes.
+func parseFileSize(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	end := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if end < 0 {
+		end = len(s)
+	}
+
+	n, err := strconv.ParseFloat(s[:end], 64)
+	if err != nil {
+		return 0, false
+	}
+
+	unit := strings.ToUpper(strings.TrimSpace(s[end:]))
+	if unit == "" || unit == "B" {
+		return n, true
+	}
+
+	exp := strings.IndexByte("KMGT", unit[0]) + 1
+	if exp == 0 {
+		return 0, false
+	}
+
+	var base float64
+	switch unit[1:] {
+	case "", "IB":
+		base = 1024
+	case "B":
+		base = 1000
+	default:
+		return 0, false
+	}
+
+	for i := 0; i < exp; i++ {
+		n *= base
+	}
+	return n, true
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,168 @@
+package table
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestLengthComparison(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"", "a", -1},
+		{"a", "", 1},
+		{"abc", "xyz", 0},
+		{"ab", "abc", -1},
+		{"abcd", "abc", 1},
+		{"请求", "ab", 0},
+		{"héllo", "hello", 0},
+		{"🤣", "ab", -1},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, LengthComparison(test.a, test.b), "%q vs %q", test.a, test.b)
+	}
+}
+
+func TestCaseInsensitiveStringComparison(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"", "a", -1},
+		{"apple", "Zebra", -1},
+		{"Zebra", "apple", 1},
+		{"abc", "abc", 0},
+		{"ABC", "abc", -1},
+		{"abc", "ABC", 1},
+		{"abc", "ABD", -1},
+		{"Ärger", "ärger", -1},
+		{"ab", "ABC", -1},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, CaseInsensitiveStringComparison(test.a, test.b), "%q vs %q", test.a, test.b)
+	}
+}
+
+func TestNaturalComparison(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"", "1", -1},
+		{"file2", "file10", -1},
+		{"file10", "file2", 1},
+		{"file10", "file10", 0},
+		{"file01", "file1", -1},
+		{"file1", "file01", 1},
+		{"file01a", "file1b", -1},
+		{"v1.10.2", "v1.9.12", 1},
+		{"a2b3", "a2b20", -1},
+		{"123456789012345678901", "99", 1},
+		{"10", "file", -1},
+		{"file", "file1", -1},
+		{"apple", "banana", -1},
+		{"Zebra", "apple", -1},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, NaturalComparison(test.a, test.b), "%q vs %q", test.a, test.b)
+	}
+}
+
+func TestFileSizeComparison(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"500", "1K", -1},
+		{"1024", "1K", 0},
+		{"1.5K", "1536", 0},
+		{"2M", "1.9M", 1},
+		{"1G", "1023M", 1},
+		{"1T", "1024G", 0},
+		{"1KB", "1000", 0},
+		{"1KiB", "1k", 0},
+		{"1MB", "1M", -1},
+		{"2 GB", "2GB", 0},
+		{"10B", "9", 1},
+		{" 4k ", "4096", 0},
+		{"", "", 0},
+		{"-", "1K", 1},
+		{"1K", "n/a", -1},
+		{"abc", "abd", -1},
+		{"1X", "1", 1},
+		{"1KX", "1", 1},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, FileSizeComparison(test.a, test.b), "%q vs %q", test.a, test.b)
+	}
+}
+
+func TestCollationComparison(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		tag      language.Tag
+		a, b     string
+		expected int
+	}{
+		{language.German, "ö", "z", -1},
+		{language.Swedish, "ö", "z", 1},
+		{language.English, "émile", "eve", -1},
+		{language.English, "Zoë", "Zoë", 0},
+		{language.Spanish, "ñu", "nube", 1},
+		{language.Spanish, "ñu", "oso", -1},
+		{language.Danish, "å", "z", 1},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, CollationComparison(test.tag)(test.a, test.b), "%v: %q vs %q", test.tag, test.a, test.b)
+	}
+}
+
+func TestRegexGroupComparison(t *testing.T) {
+	t.Parallel()
+
+	size := RegexGroupComparison(regexp.MustCompile(`size=(\S+)`), 1, FileSizeComparison)
+	optional := RegexGroupComparison(regexp.MustCompile(`^x(\d+)?`), 1, LengthComparison)
+	outOfRange := RegexGroupComparison(regexp.MustCompile(`\d+`), 1, LengthComparison)
+	negative := RegexGroupComparison(regexp.MustCompile(`\d+`), -1, LengthComparison)
+
+	tests := []struct {
+		cmp      ComparisonFunc
+		a, b     string
+		expected int
+	}{
+		{size, "a size=2K", "b size=512", 1},
+		{size, "z size=1K", "a size=1024", 0},
+		{size, "size=1K", "no size", -1},
+		{size, "none", "size=1K", 1},
+		{size, "abc", "abd", -1},
+		{optional, "x12", "x3", 1},
+		{optional, "x", "x3", 1},
+		{outOfRange, "3", "22", 1},
+		{negative, "3", "22", 1},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, test.cmp(test.a, test.b), "%q vs %q", test.a, test.b)
+	}
+}
@@ -0,0 +1,89 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (t *table) Equal(other Table) bool {
+	return t.Diff(other) == ""
+}
+
+func (t *table) Diff(other Table) string {
+	o, ok := other.(*table)
+	if !ok {
+		return "other is not a Table created by New\n"
+	}
+
+	// other is copied before locking t so that comparing a table with itself
+	// cannot deadlock. The cells are copied since SetCell changes them in
+	// place.
+	o.mu.Lock()
+	oHeader, oRows := append([]string(nil), o.header...), copyRows(o.rows)
+	oFooter := append([]string(nil), o.footer...)
+	o.mu.Unlock()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var sb strings.Builder
+
+	for i, n := 0, max(len(t.header), len(oHeader)); i < n; i++ {
+		if a, b := safeOffset(t.header, i), safeOffset(oHeader, i); a != b {
+			fmt.Fprintf(&sb, "header, column %d: %q != %q\n", i, a, b)
+		}
+	}
+
+	if len(t.rows) != len(oRows) {
+		fmt.Fprintf(&sb, "row count: %d != %d\n", len(t.rows), len(oRows))
+	}
+
+	for r := 0; r < len(t.rows) && r < len(oRows); r++ {
+		row, oRow := t.rows[r], oRows[r]
+		if (row == nil) != (oRow == nil) {
+			a, b := "separator", "row"
+			if row != nil {
+				a, b = b, a
+			}
+			fmt.Fprintf(&sb, "row %d: %s != %s\n", r, a, b)
+			continue
+		}
+		for i, n := 0, max(len(row), len(oRow)); i < n; i++ {
+			if a, b := safeOffset(row, i), safeOffset(oRow, i); a != b {
+				fmt.Fprintf(&sb, "row %d, column %d: %q != %q\n", r, i, a, b)
+			}
+		}
+	}
+
+	for i, n := 0, max(len(t.footer), len(oFooter)); i < n; i++ {
+		if a, b := safeOffset(t.footer, i), safeOffset(oFooter, i); a != b {
+			fmt.Fprintf(&sb, "footer, column %d: %q != %q\n", i, a, b)
+		}
+	}
+
+	return sb.String()
+}
+
+// DiffRows combines two versions of a row into one for displaying changes,
+// such as in an audit log. Cells that are unchanged hold their value once, and
+// changed cells hold "old→new". A cell missing from the shorter row is treated
+// as empty, so the result is as long as the longer row.
+//
+//	before := []string{"42", "alice", "admin"}
+//	after := []string{"42", "alice", "owner"}
+//	New("ID", "Name", "Role").SetRows([][]string{table.DiffRows(before, after)}).Print()
+//	// Output:
+//	// ID  Name   Role
+//	// 42  alice  admin→owner
+func DiffRows(before, after []string) []string {
+	out := make([]string, max(len(before), len(after)))
+	for i := range out {
+		a, b := safeOffset(before, i), safeOffset(after, i)
+		if a == b {
+			out[i] = a
+		} else {
+			out[i] = a + "→" + b
+		}
+	}
+	return out
+}
@@ -0,0 +1,83 @@
+package table
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable_Equal(t *testing.T) {
+	t.Parallel()
+
+	a := New("id", "name").AddRow(1, "alice").AddRow(2, "bob")
+	b := New("id", "name").WithPadding(4).AddRow(1, "alice").AddRow(2, "bob")
+
+	// configuration is ignored
+	assert.True(t, a.Equal(b))
+	assert.True(t, a.Equal(a))
+	assert.Empty(t, a.Diff(b))
+
+	// short rows match rows with empty trailing cells
+	c := New("id", "name").SetRows([][]string{{"1", "alice"}, {"2", "bob"}, {"3"}})
+	d := New("id", "name").SetRows([][]string{{"1", "alice"}, {"2", "bob"}, {"3", ""}})
+	assert.True(t, c.Equal(d))
+}
+
+func TestTable_Diff(t *testing.T) {
+	t.Parallel()
+
+	a := New("id", "name").AddRow(1, "alice").AddRow(2, "bob")
+	b := New("id", "user").AddRow(1, "alice").AddRow(2, "rob").AddRow(3, "carol")
+
+	assert.False(t, a.Equal(b))
+	assert.Equal(t, `header, column 1: "name" != "user"
+row count: 2 != 3
+row 1, column 1: "bob" != "rob"
+`, a.Diff(b))
+
+	a = New("item", "cost").AddRow("pen", 1).AddFooter("total", 1)
+	b = New("item", "cost").AddRow("pen", 1).AddFooter("total", 2)
+	assert.False(t, a.Equal(b))
+	assert.Equal(t, "footer, column 1: \"1\" != \"2\"\n", a.Diff(b))
+	assert.Equal(t, "footer, column 0: \"total\" != \"\"\n"+
+		"footer, column 1: \"1\" != \"\"\n", a.Diff(New("item", "cost").AddRow("pen", 1)))
+
+	// separators never equal empty rows
+	a = New("id").AddRow(1).AddSeparatorRow()
+	b = New("id").AddRow(1).AddRow()
+	assert.False(t, a.Equal(b))
+	assert.Equal(t, "row 1: separator != row\n", a.Diff(b))
+	assert.Equal(t, "row 1: row != separator\n", b.Diff(a))
+}
+
+func TestTable_Diff_concurrentSetCell(t *testing.T) {
+	t.Parallel()
+
+	a := New("id").AddRow(1)
+	b := New("id").AddRow(1)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			assert.NoError(t, b.SetCell(0, 0, strconv.Itoa(i)))
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		a.Diff(b)
+	}
+	<-done
+}
+
+func TestDiffRows(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		[]string{"42", "alice", "admin→owner"},
+		DiffRows([]string{"42", "alice", "admin"}, []string{"42", "alice", "owner"}))
+	assert.Equal(t,
+		[]string{"1", "→new", "gone→"},
+		DiffRows([]string{"1", "", "gone"}, []string{"1", "new"}))
+	assert.Empty(t, DiffRows(nil, nil))
+}
@@ -0,0 +1,396 @@
+package table
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+)
+
+func (t *table) ExportOrg(w io.Writer) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	header := make([]string, len(t.header))
+	for i, h := range t.header {
+		header[i] = escapeOrg(h)
+	}
+
+	rows := make([][]string, 0, len(t.rows))
+	for _, row := range t.rows {
+		if row == nil {
+			continue
+		}
+		cells := make([]string, len(t.header))
+		for j := range t.header {
+			cells[j] = escapeOrg(safeOffset(row, j))
+		}
+		rows = append(rows, cells)
+	}
+
+	widths := make([]int, len(header))
+	if !t.headerless {
+		for i, h := range header {
+			widths[i] = t.Width(h)
+		}
+	}
+	for _, row := range rows {
+		for i, v := range row {
+			widths[i] = max(widths[i], t.Width(v))
+		}
+	}
+
+	var sb strings.Builder
+	writeComment(&sb, "# ", t.ExportComment)
+	if !t.headerless {
+		t.writeOrgRow(&sb, header, widths)
+
+		sb.WriteByte('|')
+		for i, width := range widths {
+			if i > 0 {
+				sb.WriteByte('+')
+			}
+			sb.WriteString(strings.Repeat("-", width+2))
+		}
+		sb.WriteString("|\n")
+	}
+
+	for _, row := range rows {
+		t.writeOrgRow(&sb, row, widths)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// writeComment writes each line of comment to sb, preceded by prefix. Nothing
+// is written for an empty comment.
+func writeComment(sb *strings.Builder, prefix, comment string) {
+	if comment == "" {
+		return
+	}
+	for _, line := range strings.Split(comment, "\n") {
+		sb.WriteString(strings.TrimRight(prefix+line, " ") + "\n")
+	}
+}
+
+func (t *table) writeOrgRow(sb *strings.Builder, cells []string, widths []int) {
+	sb.WriteByte('|')
+	for i, v := range cells {
+		sb.WriteString(" ")
+		sb.WriteString(v)
+		sb.WriteString(t.lenOffset(v, widths[i]))
+		sb.WriteString(" |")
+	}
+	sb.WriteByte('\n')
+}
+
+// escapeOrg replaces the pipes in s, which would otherwise start a new cell,
+// with the equivalent org-mode entity.
+func escapeOrg(s string) string {
+	return strings.ReplaceAll(s, "|", `\vert{}`)
+}
+
+func (t *table) ExportJSONNested(keyColumns []int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	isKey := make(map[int]bool, len(keyColumns))
+	for _, col := range keyColumns {
+		if col < 0 || col >= len(t.header) {
+			return fmt.Errorf("table: key column %d out of range [0,%d)", col, len(t.header))
+		}
+		if isKey[col] {
+			return fmt.Errorf("table: duplicate key column %d", col)
+		}
+		isKey[col] = true
+	}
+
+	var root interface{} = []map[string]string{}
+	if len(keyColumns) > 0 {
+		root = map[string]interface{}{}
+	}
+
+	keys := t.jsonKeys()
+	for _, row := range t.rows {
+		if row == nil {
+			continue
+		}
+		leaf := make(map[string]string, len(keys)-len(keyColumns))
+		for i, h := range keys {
+			if !isKey[i] {
+				leaf[h] = safeOffset(row, i)
+			}
+		}
+
+		if len(keyColumns) == 0 {
+			root = append(root.([]map[string]string), leaf)
+			continue
+		}
+
+		level := root.(map[string]interface{})
+		for _, col := range keyColumns[:len(keyColumns)-1] {
+			key := safeOffset(row, col)
+			next, ok := level[key].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				level[key] = next
+			}
+			level = next
+		}
+
+		key := safeOffset(row, keyColumns[len(keyColumns)-1])
+		leaves, _ := level[key].([]map[string]string)
+		level[key] = append(leaves, leaf)
+	}
+
+	return json.NewEncoder(t.Writer).Encode(root)
+}
+
+func (t *table) ExportJSONArray() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	flusher, _ := t.Writer.(interface{ Flush() error })
+
+	if _, err := io.WriteString(t.Writer, "["); err != nil {
+		return err
+	}
+
+	// each row is encoded into buf and written on its own, so only a single
+	// row is ever held in memory.
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	sep := "\n"
+	keys := t.jsonKeys()
+	for _, row := range t.rows {
+		if row == nil {
+			continue
+		}
+		obj := make(map[string]string, len(keys))
+		for i, h := range keys {
+			obj[h] = safeOffset(row, i)
+		}
+
+		buf.Reset()
+		buf.WriteString(sep)
+		if err := enc.Encode(obj); err != nil {
+			return err
+		}
+		buf.Truncate(buf.Len() - 1) // drop the newline added by Encode
+		if _, err := t.Writer.Write(buf.Bytes()); err != nil {
+			return err
+		}
+		if flusher != nil {
+			if err := flusher.Flush(); err != nil {
+				return err
+			}
+		}
+		sep = ",\n"
+	}
+
+	end := "]\n"
+	if sep != "\n" {
+		end = "\n]\n"
+	}
+	if _, err := io.WriteString(t.Writer, end); err != nil {
+		return err
+	}
+	if flusher != nil {
+		return flusher.Flush()
+	}
+	return nil
+}
+
+// jsonKeys returns the keys of the objects written by the JSON exports: the
+// header, or the column indexes, such as "0", if the table has no header.
+func (t *table) jsonKeys() []string {
+	if !t.headerless {
+		return t.header
+	}
+	keys := make([]string, len(t.header))
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+	}
+	return keys
+}
+
+func (t *table) Records() [][]string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	records := make([][]string, 0, len(t.rows)+1)
+	if !t.headerless {
+		records = append(records, append([]string(nil), t.header...))
+	}
+	for _, row := range t.rows {
+		if row == nil {
+			continue
+		}
+		record := make([]string, len(t.header))
+		copy(record, row)
+		records = append(records, record)
+	}
+	return records
+}
+
+func (t *table) ExportClipboardTSV() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var sb strings.Builder
+	if !t.headerless {
+		writeTSVRow(&sb, t.header)
+	}
+	for _, row := range t.rows {
+		if row == nil {
+			continue
+		}
+		cells := make([]string, len(t.header))
+		copy(cells, row)
+		writeTSVRow(&sb, cells)
+	}
+
+	_, err := io.WriteString(t.Writer, sb.String())
+	return err
+}
+
+// writeTSVRow writes cells to sb as a line of tab-separated values, quoting
+// the cells that contain a tab, newline or double quote.
+func writeTSVRow(sb *strings.Builder, cells []string) {
+	for i, v := range cells {
+		if i > 0 {
+			sb.WriteByte('\t')
+		}
+		if strings.ContainsAny(v, "\t\r\n\"") {
+			v = `"` + strings.ReplaceAll(v, `"`, `""`) + `"`
+		}
+		sb.WriteString(v)
+	}
+	sb.WriteByte('\n')
+}
+
+func (t *table) ExportMarkdown() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	header := make([]string, len(t.header))
+	for i, h := range t.header {
+		header[i] = escapeMarkdown(h)
+	}
+
+	rows := make([][]string, 0, len(t.rows))
+	for _, row := range t.rows {
+		if row == nil {
+			continue
+		}
+		cells := make([]string, len(t.header))
+		for j := range t.header {
+			cells[j] = escapeMarkdown(safeOffset(row, j))
+		}
+		rows = append(rows, cells)
+	}
+
+	widths := make([]int, len(header))
+	if !t.headerless {
+		for i, h := range header {
+			widths[i] = t.Width(h)
+		}
+	}
+	for _, row := range rows {
+		for i, v := range row {
+			widths[i] = max(widths[i], t.Width(v))
+		}
+	}
+
+	var sb strings.Builder
+	if t.ExportComment != "" {
+		// the blank line ends the blockquote before the table starts
+		writeComment(&sb, "> ", t.ExportComment)
+		sb.WriteByte('\n')
+	}
+	if !t.headerless {
+		t.writeOrgRow(&sb, header, widths)
+
+		sb.WriteByte('|')
+		for _, width := range widths {
+			sb.WriteString(strings.Repeat("-", width+2))
+			sb.WriteByte('|')
+		}
+		sb.WriteByte('\n')
+	}
+
+	for _, row := range rows {
+		t.writeOrgRow(&sb, row, widths)
+	}
+
+	_, err := io.WriteString(t.Writer, sb.String())
+	return err
+}
+
+// writeHTMLComment writes comment to sb as an HTML comment. Runs of hyphens
+// are broken up with spaces, so the comment cannot end early. Nothing is
+// written for an empty comment.
+func writeHTMLComment(sb *strings.Builder, comment string) {
+	if comment == "" {
+		return
+	}
+	for strings.Contains(comment, "--") {
+		comment = strings.ReplaceAll(comment, "--", "- -")
+	}
+	sb.WriteString("<!--\n")
+	writeComment(sb, "", comment)
+	sb.WriteString("-->\n")
+}
+
+// escapeMarkdown escapes the pipes in s, which would otherwise start a new
+// cell, and replaces its newlines with <br> so the row stays on one line.
+func escapeMarkdown(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
+
+func (t *table) ExportHTML() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var sb strings.Builder
+	writeHTMLComment(&sb, t.ExportComment)
+	sb.WriteString("<table>\n")
+	if !t.headerless {
+		sb.WriteString("  <thead>\n")
+		writeHTMLRow(&sb, "th", t.header)
+		sb.WriteString("  </thead>\n")
+	}
+
+	sb.WriteString("  <tbody>\n")
+	for _, row := range t.rows {
+		if row == nil {
+			continue
+		}
+		cells := make([]string, len(t.header))
+		copy(cells, row)
+		writeHTMLRow(&sb, "td", cells)
+	}
+	sb.WriteString("  </tbody>\n")
+	sb.WriteString("</table>\n")
+
+	_, err := io.WriteString(t.Writer, sb.String())
+	return err
+}
+
+// writeHTMLRow writes cells to sb as a table row of tag elements, escaping
+// their text and replacing newlines with <br>.
+func writeHTMLRow(sb *strings.Builder, tag string, cells []string) {
+	sb.WriteString("    <tr>")
+	for _, v := range cells {
+		v = strings.ReplaceAll(v, "\r\n", "\n")
+		v = strings.ReplaceAll(html.EscapeString(v), "\n", "<br>")
+		fmt.Fprintf(sb, "<%s>%s</%s>", tag, v, tag)
+	}
+	sb.WriteString("</tr>\n")
+}
@@ -0,0 +1,289 @@
+package table
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable_ExportOrg(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	err := New("foo", "bar").
+		AddRow("fizz", "buzz").
+		AddRow("a|b").
+		ExportOrg(&buf)
+	assert.NoError(t, err)
+
+	expected := `| foo       | bar  |
+|-----------+------|
+| fizz      | buzz |
+| a\vert{}b |      |
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("export mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// headerless tables have no header or separator line
+	buf.Reset()
+	assert.NoError(t, NewHeaderless().AddRow("x", "y").ExportOrg(&buf))
+	assert.Equal(t, "| x | y |\n", buf.String())
+}
+
+func TestTable_ExportJSONNested(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("Region", "Country", "City", "Pop").
+		WithWriter(&buf).
+		AddRow("EU", "FR", "Paris", 2).
+		AddRow("EU", "DE", "Berlin", 4).
+		AddRow("EU", "FR", "Lyon", 1).
+		AddRow("NA", "US")
+
+	assert.NoError(t, tbl.ExportJSONNested([]int{0, 1}))
+	assert.JSONEq(t, `{
+		"EU": {
+			"DE": [{"City": "Berlin", "Pop": "4"}],
+			"FR": [{"City": "Paris", "Pop": "2"}, {"City": "Lyon", "Pop": "1"}]
+		},
+		"NA": {
+			"US": [{"City": "", "Pop": ""}]
+		}
+	}`, buf.String())
+
+	// key order determines nesting
+	buf.Reset()
+	assert.NoError(t, tbl.ExportJSONNested([]int{1}))
+	assert.JSONEq(t, `{
+		"DE": [{"Region": "EU", "City": "Berlin", "Pop": "4"}],
+		"FR": [{"Region": "EU", "City": "Paris", "Pop": "2"}, {"Region": "EU", "City": "Lyon", "Pop": "1"}],
+		"US": [{"Region": "NA", "City": "", "Pop": ""}]
+	}`, buf.String())
+
+	// no keys produces a flat array
+	buf.Reset()
+	assert.NoError(t, New("a").WithWriter(&buf).AddRow(1).AddRow(2).ExportJSONNested(nil))
+	assert.JSONEq(t, `[{"a": "1"}, {"a": "2"}]`, buf.String())
+
+	// headerless tables are keyed by column index
+	buf.Reset()
+	assert.NoError(t, NewHeaderless().WithWriter(&buf).AddRow("EU", "Paris").ExportJSONNested([]int{0}))
+	assert.JSONEq(t, `{"EU": [{"1": "Paris"}]}`, buf.String())
+
+	// invalid keys
+	assert.Error(t, tbl.ExportJSONNested([]int{4}))
+	assert.Error(t, tbl.ExportJSONNested([]int{0, 0}))
+}
+
+func TestTable_WithExportHeaderComment(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("id").
+		WithWriter(&buf).
+		WithExportHeaderComment("Generated by report\n\n2 rows").
+		AddRow(1).
+		AddRow(2)
+
+	assert.NoError(t, tbl.ExportOrg(&buf))
+	expected := `# Generated by report
+#
+# 2 rows
+| id |
+|----|
+| 1  |
+| 2  |
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("export mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// JSON has no comments
+	buf.Reset()
+	assert.NoError(t, tbl.ExportJSONNested(nil))
+	assert.NotContains(t, buf.String(), "Generated")
+
+	// an empty comment removes it
+	buf.Reset()
+	assert.NoError(t, tbl.WithExportHeaderComment("").ExportOrg(&buf))
+	assert.NotContains(t, buf.String(), "#")
+}
+
+func TestTable_ExportMarkdown(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("ID", "Note").
+		WithWriter(&buf).
+		AddRow(1, "a|b").
+		AddSeparatorRow().
+		AddRow(2)
+	assert.NoError(t, tbl.SetCell(2, 1, "two\nlines"))
+
+	assert.NoError(t, tbl.ExportMarkdown())
+	expected := `| ID | Note         |
+|----|--------------|
+| 1  | a\|b         |
+| 2  | two<br>lines |
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("export mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// the comment is written as a blockquote
+	buf.Reset()
+	assert.NoError(t, tbl.WithExportHeaderComment("Generated\n\n2 rows").ExportMarkdown())
+	assert.True(t, strings.HasPrefix(buf.String(), "> Generated\n>\n> 2 rows\n\n| ID |"), buf.String())
+
+	// headerless tables have no header or separator line
+	buf.Reset()
+	assert.NoError(t, NewHeaderless().WithWriter(&buf).AddRow("x", "y").ExportMarkdown())
+	assert.Equal(t, "| x | y |\n", buf.String())
+}
+
+func TestTable_ExportHTML(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("ID", "<Name>").
+		WithWriter(&buf).
+		AddRow(1, "Tom & Jerry").
+		AddSeparatorRow().
+		AddRow(2)
+	assert.NoError(t, tbl.SetCell(2, 1, "<b>two</b>\nlines"))
+
+	assert.NoError(t, tbl.ExportHTML())
+	expected := `<table>
+  <thead>
+    <tr><th>ID</th><th>&lt;Name&gt;</th></tr>
+  </thead>
+  <tbody>
+    <tr><td>1</td><td>Tom &amp; Jerry</td></tr>
+    <tr><td>2</td><td>&lt;b&gt;two&lt;/b&gt;<br>lines</td></tr>
+  </tbody>
+</table>
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("export mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// headerless tables have no thead
+	buf.Reset()
+	assert.NoError(t, NewHeaderless().WithWriter(&buf).AddRow("x").ExportHTML())
+	assert.Equal(t, "<table>\n  <tbody>\n    <tr><td>x</td></tr>\n  </tbody>\n</table>\n", buf.String())
+
+	// comments cannot close early
+	buf.Reset()
+	assert.NoError(t, NewHeaderless().WithWriter(&buf).WithExportHeaderComment("a --> b ---").ExportHTML())
+	assert.True(t, strings.HasPrefix(buf.String(), "<!--\na - -> b - - -\n-->\n<table>"), buf.String())
+}
+
+type flushBuffer struct {
+	bytes.Buffer
+	flushes int
+}
+
+func (b *flushBuffer) Flush() error {
+	b.flushes++
+	return nil
+}
+
+func TestTable_ExportJSONArray(t *testing.T) {
+	t.Parallel()
+
+	buf := flushBuffer{}
+	tbl := New("ID", "Name").
+		WithWriter(&buf).
+		AddRow(1, "Foobar").
+		AddSeparatorRow().
+		AddRow(1, "Fizzbuzz").
+		AddRow(3)
+
+	assert.NoError(t, tbl.ExportJSONArray())
+	expected := `[
+{"ID":"1","Name":"Foobar"},
+{"ID":"1","Name":"Fizzbuzz"},
+{"ID":"3","Name":""}
+]
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("export mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+	assert.Equal(t, 4, buf.flushes)
+
+	// empty table
+	buf.Reset()
+	assert.NoError(t, New("a").WithWriter(&buf).ExportJSONArray())
+	assert.Equal(t, "[]\n", buf.String())
+
+	// headerless tables are keyed by column index
+	buf.Reset()
+	assert.NoError(t, NewHeaderless().WithWriter(&buf).AddRow("a", "b").ExportJSONArray())
+	assert.Equal(t, "[\n{\"0\":\"a\",\"1\":\"b\"}\n]\n", buf.String())
+}
+
+func TestTable_Records(t *testing.T) {
+	t.Parallel()
+
+	tbl := New("ID", "Name").
+		AddRow(1, "Foo, Inc.").
+		AddSeparatorRow().
+		AddRow(2)
+
+	records := tbl.Records()
+	assert.Equal(t, [][]string{
+		{"ID", "Name"},
+		{"1", "Foo, Inc."},
+		{"2", ""},
+	}, records)
+
+	buf := bytes.Buffer{}
+	assert.NoError(t, csv.NewWriter(&buf).WriteAll(records))
+	assert.Equal(t, "ID,Name\n1,\"Foo, Inc.\"\n2,\n", buf.String())
+
+	// the records are copies
+	records[1][0] = "changed"
+	assert.Equal(t, "1", tbl.Records()[1][0])
+
+	// headerless tables have no header record
+	assert.Equal(t, [][]string{{"a", "b"}}, NewHeaderless().AddRow("a", "b").Records())
+}
+
+func TestTable_ExportClipboardTSV(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("ID", "Note").
+		WithWriter(&buf).
+		WithExportHeaderComment("ignored").
+		AddRow(1, "plain, with comma").
+		AddSeparatorRow().
+		AddRow(2).
+		AddRow(3, "a\ttab").
+		AddRow(4, `say "hi"`).
+		AddRow(5)
+
+	// AddRow splits values on newlines, so set a multi-line cell directly
+	assert.NoError(t, tbl.SetCell(2, 1, "two\nlines"))
+	assert.NoError(t, tbl.ExportClipboardTSV())
+	expected := "ID\tNote\n" +
+		"1\tplain, with comma\n" +
+		"2\t\"two\nlines\"\n" +
+		"3\t\"a\ttab\"\n" +
+		"4\t\"say \"\"hi\"\"\"\n" +
+		"5\t\n"
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("export mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// headerless tables have no header line
+	buf.Reset()
+	assert.NoError(t, NewHeaderless().WithWriter(&buf).AddRow("a", "b").ExportClipboardTSV())
+	assert.Equal(t, "a\tb\n", buf.String())
+}
@@ -0,0 +1,108 @@
+package table
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// BarFormatter returns a TransformFunc that renders numeric cells as a text
+// bar chart, for use with WithColumnTransform. A cell's position within
+// [lo, hi] determines how much of the bar, which is width characters long,
+// is filled. The bar is followed by the percentage, so every numeric cell
+// renders to exactly width+5 characters and the column stays aligned. Values
+// outside the range are clamped, and cells that are not numbers are returned
+// unchanged, as are all cells if hi is not greater than lo.
+//
+//	tbl.WithColumnTransform(2, table.BarFormatter(0, 100, 8))
+//	// 50 renders as: ████░░░░  50%
+func BarFormatter(lo, hi float64, width int) TransformFunc {
+	return func(s string) string {
+		v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil || math.IsNaN(v) || hi <= lo || width < 0 {
+			return s
+		}
+
+		frac := math.Min(math.Max((v-lo)/(hi-lo), 0), 1)
+		filled := int(math.Round(frac * float64(width)))
+
+		return fmt.Sprintf("%s%s %3.0f%%",
+			strings.Repeat("█", filled),
+			strings.Repeat("░", width-filled),
+			frac*100)
+	}
+}
+
+// ANSI escape sequences used by DimFormatter and BoldFormatter.
+const (
+	ansiBold = "\x1b[1m"
+	ansiDim  = "\x1b[2m"
+)
+
+// DimFormatter is a Formatter that renders text faint, to mute less important
+// cells. It only adds ANSI escape codes, so it does not change the width of
+// the text as printed. The codes are always added; use IsTerminal to decide
+// whether to apply it.
+//
+//	if tbl.IsTerminal() {
+//	  tbl.WithFirstColumnFormatter(table.DimFormatter)
+//	}
+func DimFormatter(format string, vals ...interface{}) string {
+	return ansiDim + fmt.Sprintf(format, vals...) + ansiReset
+}
+
+// BoldFormatter is a Formatter that renders text bold, to emphasize cells. Like
+// DimFormatter, it does not change the width of the text as printed and should
+// only be applied when writing to a terminal.
+//
+//	tbl.WithHeaderFormatter(table.BoldFormatter)
+func BoldFormatter(format string, vals ...interface{}) string {
+	return ansiBold + fmt.Sprintf(format, vals...) + ansiReset
+}
+
+// sparkBlocks are the levels of a sparkline, from lowest to highest.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// SparklineFormatter returns a TransformFunc that renders cells holding a list
+// of numbers separated by sep as a sparkline, for use with
+// WithColumnTransform. Each number becomes a single block whose height is
+// scaled between the smallest and largest numbers in the cell, so cells with
+// the same count of numbers have the same width. Cells with fewer than two
+// numbers, or any value that is not a number, are returned unchanged.
+//
+//	tbl.WithColumnTransform(1, table.SparklineFormatter(","))
+//	// 1,5,3,8,2 renders as: ▁▅▃█▂
+func SparklineFormatter(sep string) TransformFunc {
+	return func(s string) string {
+		fields := strings.Split(s, sep)
+		if len(fields) < 2 {
+			return s
+		}
+
+		vals := make([]float64, len(fields))
+		for i, f := range fields {
+			v, err := strconv.ParseFloat(strings.TrimSpace(f), 64)
+			if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+				return s
+			}
+			vals[i] = v
+		}
+
+		lo, hi := vals[0], vals[0]
+		for _, v := range vals {
+			lo, hi = math.Min(lo, v), math.Max(hi, v)
+		}
+
+		top := float64(len(sparkBlocks) - 1)
+		out := make([]rune, len(vals))
+		for i, v := range vals {
+			level := 0.0
+			if hi > lo {
+				level = math.Round((v - lo) / (hi - lo) * top)
+			}
+			out[i] = sparkBlocks[int(level)]
+		}
+		return string(out)
+	}
+}
@@ -0,0 +1,103 @@
+package table
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBarFormatter(t *testing.T) {
+	t.Parallel()
+
+	bar := BarFormatter(0, 200, 8)
+
+	tests := []struct {
+		in, expected string
+	}{
+		{"0", "░░░░░░░░   0%"},
+		{"100", "████░░░░  50%"},
+		{"150", "██████░░  75%"},
+		{"200", "████████ 100%"},
+		{"-10", "░░░░░░░░   0%"},
+		{"1000", "████████ 100%"},
+		{"n/a", "n/a"},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, bar(test.in), test.in)
+	}
+
+	// invalid ranges pass through
+	assert.Equal(t, "5", BarFormatter(10, 10, 8)("5"))
+}
+
+func TestBarFormatter_Table(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	New("Disk", "Used").
+		WithWriter(&buf).
+		WithColumnTransform(1, BarFormatter(0, 1, 4)).
+		AddRow("sda", 0.5).
+		AddRow("sdb", "-").
+		Print()
+
+	expected := `Disk  Used       
+sda   ██░░  50%  
+sdb   -          
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+}
+
+func TestDimFormatter(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	New("ID", "Name").
+		WithWriter(&buf).
+		WithFirstColumnFormatter(DimFormatter).
+		AddRow(1, "foo").
+		Print()
+
+	expected := "ID  Name  \n\x1b[2m1   \x1b[0mfoo   \n"
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+}
+
+func TestBoldFormatter(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "\x1b[1mID  Name\x1b[0m", BoldFormatter("%s%s", "ID  ", "Name"))
+}
+
+func TestSparklineFormatter(t *testing.T) {
+	t.Parallel()
+
+	spark := SparklineFormatter(",")
+
+	tests := []struct {
+		in, expected string
+	}{
+		{"1,5,3,8,2", "▁▅▃█▂"},
+		{"0, 7, 14", "▁▅█"},
+		{"-1,-8", "█▁"},
+		{"4,4,4", "▁▁▁"},
+		{"3", "3"},
+		{"", ""},
+		{"1,x,3", "1,x,3"},
+		{"1,,3", "1,,3"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, spark(test.in), test.in)
+	}
+
+	// other separators
+	assert.Equal(t, "▁█", SparklineFormatter(" ")("1 2"))
+}
@@ -5,5 +5,8 @@ go 1.14
 require (
 	github.com/google/go-cmp v0.6.0
 	github.com/mattn/go-runewidth v0.0.15
+	github.com/rivo/uniseg v0.2.0
 	github.com/stretchr/testify v1.9.0
+	golang.org/x/term v0.5.0
+	golang.org/x/text v0.13.0
 )
@@ -0,0 +1,120 @@
+package table
+
+import (
+	"errors"
+	"fmt"
+)
+
+// JoinType describes how rows without a matching key are handled by JoinOn.
+type JoinType int
+
+const (
+	// InnerJoin only keeps rows whose key value is present in both tables.
+	InnerJoin JoinType = iota
+
+	// LeftJoin keeps every row of the receiving table, leaving the joined
+	// cells empty when the other table has no matching key value.
+	LeftJoin
+)
+
+func (t *table) JoinOn(other Table, thisKey, otherKey int, how JoinType) (Table, error) {
+	o, ok := other.(*table)
+	if !ok {
+		return nil, errors.New("table: cannot join with a Table not created by New")
+	}
+
+	// other is copied before locking t so that joining a table with itself,
+	// or two tables with each other concurrently, cannot deadlock. The cells
+	// are copied since SetCell changes them in place.
+	o.mu.Lock()
+	oHeader, oRows := append([]string(nil), o.header...), copyRows(o.rows)
+	o.mu.Unlock()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if thisKey < 0 || thisKey >= len(t.header) {
+		return nil, fmt.Errorf("table: join key %d out of range [0,%d)", thisKey, len(t.header))
+	}
+	if otherKey < 0 || otherKey >= len(oHeader) {
+		return nil, fmt.Errorf("table: other join key %d out of range [0,%d)", otherKey, len(oHeader))
+	}
+	if how != InnerJoin && how != LeftJoin {
+		return nil, fmt.Errorf("table: unknown join type %d", how)
+	}
+
+	header := make([]string, 0, len(t.header)+len(oHeader)-1)
+	header = append(header, t.header...)
+	header = append(header, dropColumn(oHeader, otherKey)...)
+
+	out := t.withConfig(header)
+
+	for i, row := range t.rows {
+		if row == nil {
+			continue
+		}
+		key := safeOffset(row, thisKey)
+		matched := false
+
+		for _, oRow := range oRows {
+			if safeOffset(oRow, otherKey) != key {
+				continue
+			}
+			matched = true
+			out.appendRow(joinRows(row, dropColumn(oRow, otherKey), len(t.header), len(oHeader)-1), t.rowAligns(i))
+		}
+
+		if !matched && how == LeftJoin {
+			out.appendRow(joinRows(row, nil, len(t.header), len(oHeader)-1), t.rowAligns(i))
+		}
+	}
+
+	return out, nil
+}
+
+// joinRows concatenates left and right, padding (or truncating) each side to
+// the number of columns it contributes so the cells line up with the joined
+// header.
+func joinRows(left, right []string, leftWidth, rightWidth int) []string {
+	row := make([]string, leftWidth+rightWidth)
+	copy(row[:leftWidth], left)
+	copy(row[leftWidth:], right)
+	return row
+}
+
+// dropColumn returns a copy of row without the cell at idx.
+func dropColumn(row []string, idx int) []string {
+	out := make([]string, 0, len(row))
+	for i, v := range row {
+		if i != idx {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func (t *table) SplitByColumn(columnIndex int) map[string]Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if columnIndex < 0 || columnIndex >= len(t.header) {
+		return nil
+	}
+
+	out := make(map[string]Table)
+	for i, row := range t.rows {
+		if row == nil {
+			continue
+		}
+		key := safeOffset(row, columnIndex)
+
+		part, ok := out[key].(*table)
+		if !ok {
+			part = t.withConfig(append([]string(nil), t.header...))
+			out[key] = part
+		}
+		part.appendRow(append([]string(nil), row...), t.rowAligns(i))
+	}
+
+	return out
+}
@@ -0,0 +1,118 @@
+package table
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable_JoinOn(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+
+	users := New("id", "name").WithWriter(&buf).
+		AddRow(1, "alice").
+		AddRow(2, "bob").
+		AddRow(3, "carol")
+
+	orders := New("order", "user", "item").
+		AddRow("a", 1, "book").
+		AddRow("b", 3, "pen").
+		AddRow("c", 1, "lamp")
+
+	// inner join drops unmatched rows and emits one row per match
+	inner, err := users.JoinOn(orders, 0, 1, InnerJoin)
+	assert.NoError(t, err)
+	inner.Print()
+	expected := `id  name   order  item  
+1   alice  a      book  
+1   alice  c      lamp  
+3   carol  b      pen   
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// left join keeps unmatched rows with empty cells
+	buf.Reset()
+	left, err := users.JoinOn(orders, 0, 1, LeftJoin)
+	assert.NoError(t, err)
+	left.Print()
+	expected = `id  name   order  item  
+1   alice  a      book  
+1   alice  c      lamp  
+2   bob                 
+3   carol  b      pen   
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// invalid keys
+	_, err = users.JoinOn(orders, 2, 1, InnerJoin)
+	assert.Error(t, err)
+	_, err = users.JoinOn(orders, 0, -1, InnerJoin)
+	assert.Error(t, err)
+	_, err = users.JoinOn(orders, 0, 1, JoinType(42))
+	assert.Error(t, err)
+}
+
+func TestTable_SplitByColumn(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("region", "city").WithWriter(&buf).WithPadding(1).
+		AddRow("eu", "paris").
+		AddRow("us", "nyc").
+		AddRow("eu", "rome").
+		AddRow().
+		AddFooter("all", "4 cities")
+
+	parts := tbl.SplitByColumn(0)
+	assert.Len(t, parts, 3)
+
+	parts["eu"].Print()
+	expected := `region city  
+eu     paris 
+eu     rome  
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	buf.Reset()
+	parts["us"].Print()
+	assert.Contains(t, buf.String(), "nyc")
+	assert.NotContains(t, buf.String(), "paris")
+
+	// empty cells get their own table
+	assert.Contains(t, parts, "")
+
+	// invalid column index
+	assert.Nil(t, tbl.SplitByColumn(2))
+	assert.Nil(t, tbl.SplitByColumn(-1))
+}
+
+func TestTable_JoinOn_concurrentSetCell(t *testing.T) {
+	t.Parallel()
+
+	users := New("id", "name").AddRow(1, "alice")
+	orders := New("order", "user").AddRow("a", 1)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			assert.NoError(t, orders.SetCell(0, 0, strconv.Itoa(i)))
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		_, err := users.JoinOn(orders, 0, 1, InnerJoin)
+		assert.NoError(t, err)
+	}
+	<-done
+}
@@ -0,0 +1,100 @@
+package table
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ANSI escape sequences used by LiveWriter to move the cursor and clear
+// previously rendered output.
+const (
+	ansiCursorUp   = "\x1b[%dA"
+	ansiCursorDown = "\x1b[%dB"
+	ansiClearLine  = "\x1b[2K"
+	ansiClearDown  = "\x1b[J"
+)
+
+// LiveWriter repeatedly renders a Table to a terminal, updating the previous
+// output in place rather than printing the table again below it. Only the
+// lines that differ from the last render are rewritten, which avoids the
+// flicker of clearing and reprinting a frequently updated table. If the number
+// of lines changes, the previous output is cleared and reprinted in full.
+//
+// LiveWriter assumes it is the only thing writing to w between renders and
+// that w is a terminal that understands ANSI escape codes.
+//
+//	lw := table.NewLiveWriter(os.Stdout)
+//	for range ticker.C {
+//	  lw.Render(buildTable())
+//	}
+type LiveWriter struct {
+	w     io.Writer
+	lines []string
+}
+
+// NewLiveWriter creates a LiveWriter that renders to w.
+func NewLiveWriter(w io.Writer) *LiveWriter {
+	return &LiveWriter{w: w}
+}
+
+// Render writes tbl to the LiveWriter's writer, overwriting the output of the
+// previous call to Render. The table's own Writer is ignored. An error is
+// returned if tbl was not created by New or if writing fails.
+func (lw *LiveWriter) Render(tbl Table) error {
+	t, ok := tbl.(*table)
+	if !ok {
+		return errors.New("table: cannot render a Table not created by New")
+	}
+
+	buf := bytes.Buffer{}
+	t.mu.Lock()
+	t.print(&buf)
+	t.mu.Unlock()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+
+	var sb strings.Builder
+	if len(lines) == len(lw.lines) {
+		lw.writeChanges(&sb, lines)
+	} else {
+		lw.writeAll(&sb, lines)
+	}
+
+	if _, err := io.WriteString(lw.w, sb.String()); err != nil {
+		return err
+	}
+
+	lw.lines = lines
+	return nil
+}
+
+// writeChanges rewrites each line that differs from the previous render,
+// returning the cursor to the start of the line below the table.
+func (lw *LiveWriter) writeChanges(sb *strings.Builder, lines []string) {
+	for i, line := range lines {
+		if line == lw.lines[i] {
+			continue
+		}
+
+		offset := len(lines) - i
+		fmt.Fprintf(sb, ansiCursorUp, offset)
+		sb.WriteString("\r" + ansiClearLine + line)
+		fmt.Fprintf(sb, ansiCursorDown, offset)
+		sb.WriteString("\r")
+	}
+}
+
+// writeAll clears the previous render, if any, and writes every line.
+func (lw *LiveWriter) writeAll(sb *strings.Builder, lines []string) {
+	if len(lw.lines) > 0 {
+		fmt.Fprintf(sb, ansiCursorUp, len(lw.lines))
+		sb.WriteString("\r" + ansiClearDown)
+	}
+
+	for _, line := range lines {
+		sb.WriteString(line + "\n")
+	}
+}
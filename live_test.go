@@ -0,0 +1,45 @@
+package table
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLiveWriter_Render(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	lw := NewLiveWriter(&buf)
+
+	tblBuf := bytes.Buffer{}
+	tbl := New("foo", "bar").WithWriter(&tblBuf).AddRow("fizz", "buzz").AddRow("cat", "dog")
+
+	// first render writes the whole table
+	assert.NoError(t, lw.Render(tbl))
+	assert.Equal(t, "foo   bar   \nfizz  buzz  \ncat   dog   \n", buf.String())
+
+	// unchanged renders write nothing
+	buf.Reset()
+	assert.NoError(t, lw.Render(tbl))
+	assert.Empty(t, buf.String())
+
+	// changed lines are rewritten in place
+	buf.Reset()
+	tbl.SetRows([][]string{{"fizz", "buzz"}, {"cow", "pig"}})
+	assert.NoError(t, lw.Render(tbl))
+	assert.Equal(t, "\x1b[1A\r\x1b[2Kcow   pig   \x1b[1B\r", buf.String())
+
+	// a different number of lines reprints the table
+	buf.Reset()
+	tbl.AddRow("a", "b")
+	assert.NoError(t, lw.Render(tbl))
+	assert.Equal(t, "\x1b[3A\r\x1b[Jfoo   bar   \nfizz  buzz  \ncow   pig   \na     b     \n", buf.String())
+
+	// the table's own writer is untouched
+	assert.Empty(t, tblBuf.String())
+
+	// only tables created by New are supported
+	assert.Error(t, lw.Render(nil))
+}
@@ -0,0 +1,186 @@
+package table
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ColumnType describes the kind of data held by a column, as inferred from
+// its cell values.
+type ColumnType int
+
+const (
+	// TypeString is used for columns that hold arbitrary text, or no values
+	// at all.
+	TypeString ColumnType = iota
+
+	// TypeNumber is used for columns whose non-empty cells are all numbers.
+	TypeNumber
+
+	// TypeBool is used for columns whose non-empty cells are all booleans as
+	// understood by strconv.ParseBool, such as "true" or "F".
+	TypeBool
+)
+
+// String returns the name of the ColumnType.
+func (ct ColumnType) String() string {
+	switch ct {
+	case TypeNumber:
+		return "number"
+	case TypeBool:
+		return "bool"
+	default:
+		return "string"
+	}
+}
+
+// ColumnSchema describes a single column of a Table.
+type ColumnSchema struct {
+	// Header is the column's header text.
+	Header string
+
+	// Type is the kind of data inferred from the column's cells.
+	Type ColumnType
+
+	// Width is the width of the widest cell in the column, including the
+	// header, as calculated by the table's WidthFunc. Padding is not included.
+	Width int
+}
+
+func (t *table) Schema() []ColumnSchema {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	rows := t.displayRows()
+	t.measureWidths(rows)
+
+	schema := make([]ColumnSchema, len(t.header))
+	for i, h := range t.header {
+		schema[i] = ColumnSchema{
+			Header: h,
+			Type:   t.columnType(i),
+			Width:  t.widths[i] - t.Padding,
+		}
+	}
+	return schema
+}
+
+func (t *table) ColumnIndex(name string) (int, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, h := range t.header {
+		if h == name {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+func (t *table) NumRows() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return len(t.rows)
+}
+
+func (t *table) NumColumns() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return len(t.header)
+}
+
+func (t *table) GetCell(row, col int) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if row < 0 || row >= len(t.rows) {
+		return "", fmt.Errorf("table: row %d out of range [0,%d)", row, len(t.rows))
+	}
+	if col < 0 || col >= len(t.header) {
+		return "", fmt.Errorf("table: column %d out of range [0,%d)", col, len(t.header))
+	}
+	if t.rows[row] == nil {
+		return "", fmt.Errorf("table: row %d is a separator row", row)
+	}
+	return safeOffset(t.rows[row], col), nil
+}
+
+// columnType infers the ColumnType of the column at col from its stored
+// values. Numbers take precedence over booleans, so a column of ones and zeros
+// is considered numeric. Empty cells are ignored.
+func (t *table) columnType(col int) ColumnType {
+	numbers, bools, values := 0, 0, 0
+	for _, row := range t.rows {
+		v := safeOffset(row, col)
+		if v == "" {
+			continue
+		}
+		values++
+
+		if _, err := strconv.ParseFloat(v, 64); err == nil {
+			numbers++
+		}
+		if _, err := strconv.ParseBool(v); err == nil {
+			bools++
+		}
+	}
+
+	switch {
+	case values == 0:
+		return TypeString
+	case numbers == values:
+		return TypeNumber
+	case bools == values:
+		return TypeBool
+	default:
+		return TypeString
+	}
+}
+
+func (t *table) EstimateWidths(sampleRows [][]string) []int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	widths := make([]int, len(t.header))
+	for i, h := range t.displayHeader()[:len(t.header)] {
+		widths[i] = t.Width(h)
+	}
+
+	for _, row := range sampleRows {
+		for i, v := range row {
+			if i >= len(widths) {
+				break
+			}
+			widths[i] = max(widths[i], t.cellWidth(t.displayCell(i, v)))
+		}
+	}
+
+	return widths
+}
+
+func (t *table) WidestRow() (index int, width int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	suffixes := 0
+	for i := 0; i < t.columnCount(); i++ {
+		suffixes += t.Width(t.columnSuffix(i))
+	}
+
+	index = -1
+	for i, row := range t.displayRows() {
+		if row == nil {
+			continue
+		}
+		w := suffixes
+		for _, v := range row {
+			w += t.cellWidth(v) + t.Padding
+		}
+		if index < 0 || w > width {
+			index, width = i, w
+		}
+	}
+	return index, width
+}
@@ -0,0 +1,136 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColumnType_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "string", TypeString.String())
+	assert.Equal(t, "number", TypeNumber.String())
+	assert.Equal(t, "bool", TypeBool.String())
+}
+
+func TestTable_Schema(t *testing.T) {
+	t.Parallel()
+
+	tbl := New("ID", "Name", "Active", "Flag", "Empty").
+		WithZeroPad(0, 4).
+		AddRow(1, "foo", true, 1).
+		AddRow(22, "barbaz", "F", "0").
+		AddRow("", "x", "", "yes")
+
+	expected := []ColumnSchema{
+		{Header: "ID", Type: TypeNumber, Width: 4},
+		{Header: "Name", Type: TypeString, Width: 6},
+		{Header: "Active", Type: TypeBool, Width: 6},
+		{Header: "Flag", Type: TypeString, Width: 4},
+		{Header: "Empty", Type: TypeString, Width: 5},
+	}
+	assert.Equal(t, expected, tbl.Schema())
+
+	assert.Empty(t, New().Schema())
+
+	// the footer counts towards the widths
+	footed := New("Item").AddRow("pen").AddFooter("grand total")
+	assert.Equal(t, 11, footed.Schema()[0].Width)
+}
+
+func TestTable_ColumnIndex(t *testing.T) {
+	t.Parallel()
+
+	tbl := New("ID", "Name", "Name").
+		WithColumnHeaderIcon(0, "#").
+		WithRunningTotal(0, "Total")
+
+	i, ok := tbl.ColumnIndex("Name")
+	assert.True(t, ok)
+	assert.Equal(t, 1, i)
+
+	i, ok = tbl.ColumnIndex("ID")
+	assert.True(t, ok)
+	assert.Equal(t, 0, i)
+
+	for _, name := range []string{"name", "# ID", "Total", ""} {
+		i, ok = tbl.ColumnIndex(name)
+		assert.False(t, ok, name)
+		assert.Equal(t, -1, i, name)
+	}
+}
+
+func TestTable_GetCell(t *testing.T) {
+	t.Parallel()
+
+	tbl := New("ID", "Name").
+		WithColumnCase(1, CaseUpper).
+		AddRow(1, "foo").
+		AddRow(2).
+		AddSeparatorRow()
+
+	assert.Equal(t, 3, tbl.NumRows())
+	assert.Equal(t, 2, tbl.NumColumns())
+
+	v, err := tbl.GetCell(0, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", v)
+
+	// missing values are empty
+	v, err = tbl.GetCell(1, 1)
+	assert.NoError(t, err)
+	assert.Empty(t, v)
+
+	// out of range or separator rows
+	for _, idx := range [][2]int{{-1, 0}, {3, 0}, {0, -1}, {0, 2}, {2, 0}} {
+		v, err = tbl.GetCell(idx[0], idx[1])
+		assert.Error(t, err, idx)
+		assert.Empty(t, v, idx)
+	}
+}
+
+func TestTable_EstimateWidths(t *testing.T) {
+	t.Parallel()
+
+	tbl := New("ID", "Name").
+		WithZeroPad(0, 4).
+		AddRow(1, "a very long name that is not part of the sample")
+
+	widths := tbl.EstimateWidths([][]string{
+		{"1", "foo"},
+		{"22", "barbaz", "ignored"},
+		{},
+	})
+	assert.Equal(t, []int{4, 6}, widths)
+
+	// the sample is not added to the table
+	assert.Equal(t, 47, tbl.Schema()[1].Width)
+
+	assert.Equal(t, []int{2, 4}, tbl.EstimateWidths(nil))
+}
+
+func TestTable_WidestRow(t *testing.T) {
+	t.Parallel()
+
+	tbl := New("ID", "Name").
+		WithColumnRightBorder(0, '|').
+		AddRow(1, "foo").
+		AddSeparatorRow().
+		AddRow(22, "barbaz").
+		AddRow(3, "quux")
+
+	// "22" and "barbaz", each with 2 padding, plus "| "
+	i, w := tbl.WidestRow()
+	assert.Equal(t, 2, i)
+	assert.Equal(t, 14, w)
+
+	// the first of equally wide rows wins
+	i, w = New("a").AddRow("x").AddRow("y").WithPadding(0).WidestRow()
+	assert.Equal(t, 0, i)
+	assert.Equal(t, 1, w)
+
+	i, w = New("a").WidestRow()
+	assert.Equal(t, -1, i)
+	assert.Equal(t, 0, w)
+}
@@ -0,0 +1,201 @@
+package table
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// tableState is the JSON representation of a table used by MarshalState and
+// UnmarshalState. Function-valued settings, and the Writer, cannot be
+// serialized and are left out.
+type tableState struct {
+	Header     []string      `json:"header"`
+	Headerless bool          `json:"headerless,omitempty"`
+	Rows       [][]string    `json:"rows"`
+	Footer     []string      `json:"footer,omitempty"`
+	Aligns     [][]Alignment `json:"aligns,omitempty"`
+
+	Padding              int                       `json:"padding"`
+	HeaderSeparatorRune  rune                      `json:"headerSeparatorRune,omitempty"`
+	FooterSeparatorRune  rune                      `json:"footerSeparatorRune,omitempty"`
+	ColumnSeparatorRunes map[int]rune              `json:"columnSeparatorRunes,omitempty"`
+	GroupBoundaries      map[int]bool              `json:"groupBoundaries,omitempty"`
+	RightBorders         map[int]rune              `json:"rightBorders,omitempty"`
+	SeparatorJunction    rune                      `json:"separatorJunction,omitempty"`
+	Style                *TableStyle               `json:"style,omitempty"`
+	GroupSeparator       string                    `json:"groupSeparator,omitempty"`
+	HeaderIcons          map[int]string            `json:"headerIcons,omitempty"`
+	ZeroPad              map[int]int               `json:"zeroPad,omitempty"`
+	DecimalPlaces        map[int]int               `json:"decimalPlaces,omitempty"`
+	ThousandsSeparators  map[int]rune              `json:"thousandsSeparators,omitempty"`
+	SmartAligns          map[int]bool              `json:"smartAligns,omitempty"`
+	Subfields            map[int]string            `json:"subfields,omitempty"`
+	UnitColumns          map[int]bool              `json:"unitColumns,omitempty"`
+	OutlierCaps          map[int]float64           `json:"outlierCaps,omitempty"`
+	RunningTotals        []runningTotal            `json:"runningTotals,omitempty"`
+	RowTotal             *rowTotal                 `json:"rowTotal,omitempty"`
+	RowTotalStrict       bool                      `json:"rowTotalStrict,omitempty"`
+	Abbreviations        map[int]map[string]string `json:"abbreviations,omitempty"`
+	Cases                map[int]CaseMode          `json:"cases,omitempty"`
+	BoolTexts            map[int]boolTexts         `json:"boolTexts,omitempty"`
+	ColumnWidths         []int                     `json:"columnWidths,omitempty"`
+	ColumnAligns         []Alignment               `json:"columnAligns,omitempty"`
+	MaxWidths            []int                     `json:"maxWidths,omitempty"`
+	ExactWidths          bool                      `json:"exactWidths,omitempty"`
+	OverflowFootnotes    bool                      `json:"overflowFootnotes,omitempty"`
+	LineNumbers          bool                      `json:"lineNumbers,omitempty"`
+	PlainMode            bool                      `json:"plainMode,omitempty"`
+	BoolSymbols          bool                      `json:"boolSymbols"`
+	NoTruncate           bool                      `json:"noTruncate,omitempty"`
+	UniqueHeaders        bool                      `json:"uniqueHeaders,omitempty"`
+	VisibleWhitespace    bool                      `json:"visibleWhitespace,omitempty"`
+	AutoLinkURLs         bool                      `json:"autoLinkURLs,omitempty"`
+	ContinuationMarker   string                    `json:"continuationMarker,omitempty"`
+	PanelTitle           string                    `json:"panelTitle,omitempty"`
+	ExportComment        string                    `json:"exportComment,omitempty"`
+	HeaderAtBottom       bool                      `json:"headerAtBottom,omitempty"`
+	TotalWidth           int                       `json:"totalWidth,omitempty"`
+	LastColumnFlushRight bool                      `json:"lastColumnFlushRight,omitempty"`
+	TreeIndent           string                    `json:"treeIndent"`
+	Normalize            bool                      `json:"normalize,omitempty"`
+	NormalizationForm    norm.Form                 `json:"normalizationForm,omitempty"`
+}
+
+func (t *table) MarshalState() ([]byte, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return json.Marshal(tableState{
+		Header:     t.header,
+		Headerless: t.headerless,
+		Rows:       t.rows,
+		Footer:     t.footer,
+		Aligns:     t.cellAligns,
+
+		Padding:              t.Padding,
+		HeaderSeparatorRune:  t.HeaderSeparatorRune,
+		FooterSeparatorRune:  t.FooterSeparatorRune,
+		ColumnSeparatorRunes: t.ColumnSeparatorRunes,
+		GroupBoundaries:      t.GroupBoundaries,
+		RightBorders:         t.RightBorders,
+		SeparatorJunction:    t.SeparatorJunction,
+		Style:                t.Style,
+		GroupSeparator:       t.GroupSeparator,
+		HeaderIcons:          t.HeaderIcons,
+		ZeroPad:              t.ZeroPad,
+		DecimalPlaces:        t.DecimalPlaces,
+		ThousandsSeparators:  t.ThousandsSeparators,
+		SmartAligns:          t.SmartAligns,
+		Subfields:            t.Subfields,
+		UnitColumns:          t.UnitColumns,
+		OutlierCaps:          t.OutlierCaps,
+		RunningTotals:        t.RunningTotals,
+		RowTotal:             t.RowTotal,
+		RowTotalStrict:       t.RowTotalStrict,
+		Abbreviations:        t.Abbreviations,
+		Cases:                t.Cases,
+		BoolTexts:            t.BoolTexts,
+		ColumnWidths:         t.columnWidths,
+		ColumnAligns:         t.columnAligns,
+		MaxWidths:            t.maxWidths,
+		ExactWidths:          t.ExactWidths,
+		OverflowFootnotes:    t.OverflowFootnotes,
+		LineNumbers:          t.LineNumbers,
+		PlainMode:            t.PlainMode,
+		BoolSymbols:          t.BoolSymbols,
+		NoTruncate:           t.NoTruncate,
+		UniqueHeaders:        t.UniqueHeaders,
+		VisibleWhitespace:    t.VisibleWhitespace,
+		AutoLinkURLs:         t.AutoLinkURLs,
+		ContinuationMarker:   t.ContinuationMarker,
+		PanelTitle:           t.PanelTitle,
+		ExportComment:        t.ExportComment,
+		HeaderAtBottom:       t.HeaderAtBottom,
+		TotalWidth:           t.TotalWidth,
+		LastColumnFlushRight: t.LastColumnFlushRight,
+		TreeIndent:           t.TreeIndent,
+		Normalize:            t.NormalizeUnicode,
+		NormalizationForm:    t.NormalizationForm,
+	})
+}
+
+// UnmarshalState creates a Table from the output of MarshalState. Its rows and
+// all of the settings that are not functions survive the round-trip, such as
+// the padding, separator runes, per-column options like WithZeroPad, and the
+// flags set by methods like WithLineNumbers. Formatters, the WidthFunc, column
+// transforms, threshold coloring, row color cycles, the LineRenderer and the
+// Writer are set to the package defaults, or left unset, as by New, and must
+// be configured again.
+//
+//	data, _ := tbl.MarshalState()
+//	// ... later
+//	tbl, err := table.UnmarshalState(data)
+func UnmarshalState(data []byte) (Table, error) {
+	var s tableState
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("table: invalid state: %w", err)
+	}
+	if s.Aligns != nil && len(s.Aligns) != len(s.Rows) {
+		return nil, fmt.Errorf("table: invalid state: %d rows but %d row alignments", len(s.Rows), len(s.Aligns))
+	}
+
+	t := New().(*table)
+	t.header = s.Header
+	if t.header == nil {
+		t.header = []string{}
+	}
+	t.headerless = s.Headerless
+	t.rows = s.Rows
+	t.footer = s.Footer
+	t.cellAligns = s.Aligns
+	t.padRows()
+
+	t.Padding = max(s.Padding, 0)
+	t.HeaderSeparatorRune = s.HeaderSeparatorRune
+	t.FooterSeparatorRune = s.FooterSeparatorRune
+	t.ColumnSeparatorRunes = s.ColumnSeparatorRunes
+	t.GroupBoundaries = s.GroupBoundaries
+	t.RightBorders = s.RightBorders
+	t.SeparatorJunction = s.SeparatorJunction
+	t.Style = s.Style
+	t.GroupSeparator = s.GroupSeparator
+	t.HeaderIcons = s.HeaderIcons
+	t.ZeroPad = s.ZeroPad
+	t.DecimalPlaces = s.DecimalPlaces
+	t.ThousandsSeparators = s.ThousandsSeparators
+	t.SmartAligns = s.SmartAligns
+	t.Subfields = s.Subfields
+	t.UnitColumns = s.UnitColumns
+	t.OutlierCaps = s.OutlierCaps
+	t.RunningTotals = s.RunningTotals
+	t.RowTotal = s.RowTotal
+	t.RowTotalStrict = s.RowTotalStrict
+	t.Abbreviations = s.Abbreviations
+	t.Cases = s.Cases
+	t.BoolTexts = s.BoolTexts
+	t.columnWidths = s.ColumnWidths
+	t.columnAligns = s.ColumnAligns
+	t.maxWidths = s.MaxWidths
+	t.ExactWidths = s.ExactWidths
+	t.OverflowFootnotes = s.OverflowFootnotes
+	t.LineNumbers = s.LineNumbers
+	t.PlainMode = s.PlainMode
+	t.BoolSymbols = s.BoolSymbols
+	t.NoTruncate = s.NoTruncate
+	t.UniqueHeaders = s.UniqueHeaders
+	t.VisibleWhitespace = s.VisibleWhitespace
+	t.AutoLinkURLs = s.AutoLinkURLs
+	t.ContinuationMarker = s.ContinuationMarker
+	t.PanelTitle = s.PanelTitle
+	t.ExportComment = s.ExportComment
+	t.HeaderAtBottom = s.HeaderAtBottom
+	t.TotalWidth = s.TotalWidth
+	t.LastColumnFlushRight = s.LastColumnFlushRight
+	t.TreeIndent = s.TreeIndent
+	t.NormalizeUnicode = s.Normalize
+	t.NormalizationForm = s.NormalizationForm
+
+	return t, nil
+}
@@ -0,0 +1,56 @@
+package table
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable_MarshalState(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("ID", "Name", "Cost").
+		WithWriter(&buf).
+		WithPadding(1).
+		WithHeaderSeparatorRow('=').
+		WithLineNumbers(true).
+		WithZeroPad(0, 3).
+		WithRunningTotal(2, "Total").
+		AddRow(1, "foo", 1.5).
+		AddRowAligned([]Alignment{AlignLeft, AlignRight}, 2, "bar", 2).
+		AddSeparatorRow().
+		AddRow(3, "baz", 4)
+
+	tbl.Print()
+	expected := buf.String()
+
+	data, err := tbl.MarshalState()
+	assert.NoError(t, err)
+
+	restored, err := UnmarshalState(data)
+	assert.NoError(t, err)
+	assert.True(t, restored.Equal(tbl))
+
+	buf.Reset()
+	restored.WithWriter(&buf).Print()
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+}
+
+func TestUnmarshalState(t *testing.T) {
+	t.Parallel()
+
+	_, err := UnmarshalState([]byte("not json"))
+	assert.Error(t, err)
+
+	_, err = UnmarshalState([]byte(`{"header":["a"],"rows":[["1"],["2"]],"aligns":[[0]]}`))
+	assert.Error(t, err)
+
+	tbl, err := UnmarshalState([]byte(`{"header":["a"],"rows":[["1"]]}`))
+	assert.NoError(t, err)
+	assert.True(t, tbl.Equal(New("a").AddRow(1)))
+}
@@ -0,0 +1,145 @@
+package table
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// TableStyle describes the lines drawn around and between the cells of a
+// table. See WithStyle.
+type TableStyle struct {
+	// Horizontal and Vertical are the runes of the lines between rows and
+	// between columns.
+	Horizontal, Vertical rune
+
+	// TopLeft, TopJunction and TopRight are drawn where the top border meets
+	// the left edge, a column line and the right edge.
+	TopLeft, TopJunction, TopRight rune
+
+	// LeftJunction, Cross and RightJunction are drawn where a line between
+	// rows meets the left edge, a column line and the right edge.
+	LeftJunction, Cross, RightJunction rune
+
+	// BottomLeft, BottomJunction and BottomRight are drawn where the bottom
+	// border meets the left edge, a column line and the right edge.
+	BottomLeft, BottomJunction, BottomRight rune
+
+	// OuterBorder draws lines above and below the table.
+	OuterBorder bool
+
+	// SideBorders draws lines to the left and right of the table.
+	SideBorders bool
+
+	// HeaderSeparator draws a line between the header and the rows.
+	HeaderSeparator bool
+
+	// RowSeparators draws a line between each row.
+	RowSeparators bool
+}
+
+var (
+	// StyleASCII draws a grid with ASCII characters only.
+	//
+	//	+----+------+
+	//	| ID | Name |
+	//	+----+------+
+	//	| 1  | foo  |
+	//	+----+------+
+	StyleASCII = TableStyle{
+		Horizontal: '-', Vertical: '|',
+		TopLeft: '+', TopJunction: '+', TopRight: '+',
+		LeftJunction: '+', Cross: '+', RightJunction: '+',
+		BottomLeft: '+', BottomJunction: '+', BottomRight: '+',
+		OuterBorder: true, SideBorders: true, HeaderSeparator: true,
+	}
+
+	// StyleRounded draws a grid of box-drawing lines with rounded corners.
+	//
+	//	╭────┬──────╮
+	//	│ ID │ Name │
+	//	├────┼──────┤
+	//	│ 1  │ foo  │
+	//	╰────┴──────╯
+	StyleRounded = TableStyle{
+		Horizontal: '─', Vertical: '│',
+		TopLeft: '╭', TopJunction: '┬', TopRight: '╮',
+		LeftJunction: '├', Cross: '┼', RightJunction: '┤',
+		BottomLeft: '╰', BottomJunction: '┴', BottomRight: '╯',
+		OuterBorder: true, SideBorders: true, HeaderSeparator: true,
+	}
+
+	// StyleDouble draws a grid of double box-drawing lines.
+	//
+	//	╔════╦══════╗
+	//	║ ID ║ Name ║
+	//	╠════╬══════╣
+	//	║ 1  ║ foo  ║
+	//	╚════╩══════╝
+	StyleDouble = TableStyle{
+		Horizontal: '═', Vertical: '║',
+		TopLeft: '╔', TopJunction: '╦', TopRight: '╗',
+		LeftJunction: '╠', Cross: '╬', RightJunction: '╣',
+		BottomLeft: '╚', BottomJunction: '╩', BottomRight: '╝',
+		OuterBorder: true, SideBorders: true, HeaderSeparator: true,
+	}
+
+	// StyleMarkdown lays the table out as a GitHub-flavored Markdown table.
+	// Pipes in cell values are not escaped.
+	//
+	//	| ID | Name |
+	//	|----|------|
+	//	| 1  | foo  |
+	StyleMarkdown = TableStyle{
+		Horizontal: '-', Vertical: '|',
+		LeftJunction: '|', Cross: '|', RightJunction: '|',
+		SideBorders: true, HeaderSeparator: true,
+	}
+)
+
+func (t *table) WithStyle(style TableStyle) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if style == (TableStyle{}) {
+		t.Style = nil
+		return t
+	}
+	t.Style = &style
+	return t
+}
+
+// linePrefix returns the text printed before the first column on every line.
+func (t *table) linePrefix() string {
+	if t.Style == nil || !t.Style.SideBorders {
+		return ""
+	}
+	return string(t.Style.Vertical) + " "
+}
+
+// printStyleRule prints a horizontal line of the Style spanning every column,
+// with left, mid and right drawn where it meets the left edge, the lines
+// between columns and the right edge.
+func (t *table) printStyleRule(w io.Writer, left, mid, right rune) {
+	h := t.Style.Horizontal
+
+	var sb strings.Builder
+	if t.LineNumbers {
+		sb.WriteString(strings.Repeat(" ", t.numberWidth+t.Padding))
+	}
+	if t.Style.SideBorders {
+		sb.WriteRune(left)
+		sb.WriteRune(h)
+	}
+	for i, width := range t.widths {
+		sb.WriteString(t.fill(h, width))
+		switch {
+		case i < len(t.widths)-1:
+			sb.WriteRune(mid)
+			sb.WriteRune(h)
+		case t.Style.SideBorders:
+			sb.WriteRune(right)
+		}
+	}
+	fmt.Fprintln(w, sb.String())
+}
@@ -26,11 +26,22 @@
 package table
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"math"
+	"math/big"
 	"os"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+	"golang.org/x/term"
+	"golang.org/x/text/unicode/norm"
 )
 
 // These are the default properties for all Tables created from this package
@@ -50,6 +61,13 @@ var (
 
 	// DefaultWidthFunc specifies the default WidthFunc for calculating column widths
 	DefaultWidthFunc WidthFunc = utf8.RuneCountInString
+
+	// DefaultTreeIndent specifies the indentation added per level by AddTreeRow.
+	DefaultTreeIndent = "  "
+
+	// DefaultBoolSymbols specifies whether boolean columns are printed as
+	// colored symbols when writing to a terminal. See WithBoolSymbols.
+	DefaultBoolSymbols = false
 )
 
 // Formatter functions expose a fmt.Sprintf signature that can be used to modify
@@ -73,9 +91,49 @@ type Formatter func(string, ...interface{}) string
 // accomodate multi-cell characters (such as emoji or CJK characters).
 type WidthFunc func(string) int
 
+// A Threshold pairs a minimum numeric value with the Formatter applied to rows
+// that reach it. See WithThresholdColoring.
+type Threshold struct {
+	Value     float64
+	Formatter Formatter
+}
+
+// A TransformFunc rewrites the value of a cell before it is measured and
+// printed. Unlike a Formatter, a TransformFunc may change the width of the
+// text, since column widths are calculated from the transformed value.
+type TransformFunc func(string) string
+
+// A StringerFunc converts a value passed to AddRow, or another method adding
+// rows, to the text of its cell. It returns false to fall back to fmt.Sprint
+// for values it does not handle. See WithValueStringer.
+//
+//	func(v interface{}) (string, bool) {
+//	  if t, ok := v.(time.Time); ok {
+//	    return t.Format("2006-01-02"), true
+//	  }
+//	  return "", false
+//	}
+type StringerFunc func(v interface{}) (string, bool)
+
+// A LineRenderer combines the cells of a line into the text that is printed,
+// without the trailing newline. The cells are already padded to their column
+// widths and have any formatters applied. See WithLineRenderer.
+//
+//	func(cells []string) string {
+//	  return "| " + strings.Join(cells, "| ") + "|"
+//	}
+type LineRenderer func(cells []string) string
+
 // Table describes the interface for building up a tabular representation of data.
 // It exposes fluent/chainable methods for convenient table building.
 //
+// Tables created by New are safe for concurrent use by multiple goroutines.
+// Each method holds the table's lock for its duration, so a Print never
+// observes a partially applied change. Print renders the whole table before
+// writing it with a single call to the Writer's Write method, so concurrent
+// Print calls sharing a Writer do not interleave their lines as long as the
+// Writer's Write is itself safe for concurrent use, as with os.File.
+//
 // WithHeaderFormatter and WithFirstColumnFormatter sets the Formatter for the
 // header and first column, respectively. If nil is passed in (the default), no
 // formatting will be applied.
@@ -84,20 +142,681 @@ type WidthFunc func(string) int
 //	  return strings.ToUpper(fmt.Sprintf(f, v...))
 //	})
 //
+// WithColumnFormatter sets the Formatter for the cells of the column at
+// columnIndex, such as to color a status column. Formatters are applied to a
+// cell in order: the first column's Formatter, then the column's Formatter,
+// then any row Formatter set with WithThresholdColoring or WithRowColorCycle,
+// so the row's formatting wraps the column's. The header is not affected.
+// Passing nil removes the Formatter. A negative columnIndex is ignored.
+//
+//	New("Host", "Status").WithColumnFormatter(1, color.New(color.FgGreen).SprintfFunc())
+//
 // WithPadding specifies the minimum padding between cells in a row and defaults
 // to DefaultPadding. Padding values less than or equal to zero apply no extra
 // padding between the columns.
 //
 //	New("foo", "bar").WithPadding(3)
 //
+// WithColumnAlignment aligns each column within its width according to the
+// corresponding entry of alignments, such as AlignRight for a column of
+// amounts. The header and header separator row are aligned the same way as
+// the cells. Columns without an entry are left-aligned, and passing no
+// alignments removes them all. Cells added with an alignment by
+// AddRowAligned keep it.
+//
+//	New("Item", "Cost").WithColumnAlignment(table.AlignLeft, table.AlignRight).
+//	  AddRow("pen", "1.50").AddRow("ink", "12.00").Print()
+//	// Output:
+//	// Item   Cost
+//	// pen    1.50
+//	// ink   12.00
+//
 // WithWriter modifies the writer which Print outputs to, defaulting to DefaultWriter
 // when instantiated. If nil is passed, os.Stdout will be used.
 //
 //	New("foo", "bar").WithWriter(os.Stderr)
 //
+// AddWriter adds w as another writer which Print outputs to, alongside the
+// current one, by combining them with io.MultiWriter. This allows, for
+// example, printing a table to stdout while also saving it to a log file. As
+// the combined writer is not a terminal, options that only apply to terminals,
+// such as WithBoolSymbols, are disabled. If nil is passed, the writer is left
+// unchanged.
+//
+//	New("foo", "bar").WithWriter(os.Stdout).AddWriter(logFile)
+//
 // WithWidthFunc sets the function used to calculate the width of the string in
 // a column. By default, the number of utf8 runes in the string is used.
 //
+// WithColumnHeaderSeparatorRune overrides the rune used for the header separator
+// row beneath the column at columnIndex, falling back to the rune set with
+// WithHeaderSeparatorRow for all other columns. Setting an override prints the
+// separator row even if WithHeaderSeparatorRow was not called, leaving the
+// other columns blank. Passing a zero rune removes the override.
+//
+//	New("ID", "Name").WithHeaderSeparatorRow('-').WithColumnHeaderSeparatorRune(0, '=')
+//	// Output:
+//	// ID  Name
+//	// ==  ----
+//
+// WithColumnGroupBoundaries visually groups columns by inserting sep after each
+// of the columns at the indices in afterColumns. The separator is printed on
+// every line, including the header, in addition to the usual padding. Calling
+// it again replaces the previous boundaries; passing no columns removes them.
+//
+//	New("Name", "Q1", "Q2", "Q3", "Q4").WithColumnGroupBoundaries([]int{0, 2}, "‖ ")
+//	// Output:
+//	// Name  ‖ Q1  Q2  ‖ Q3  Q4
+//
+// WithColumnRightBorder draws r, followed by a space, after the column at
+// columnIndex on every line, including the header and header separator row,
+// for a ledger-style vertical rule between selected columns. The border is
+// printed after the column's padding and before any column group separator.
+// Passing a zero rune removes the border.
+//
+//	New("Account", "Debit", "Credit").WithColumnRightBorder(0, '│').AddRow("cash", 100, "")
+//	// Output:
+//	// Account  │ Debit  Credit
+//	// cash     │ 100
+//
+// WithSeparatorJunction joins the header separator row, and the rules added
+// by AddSeparatorRow, into a connected line. The separator of each column
+// spans the column's full width, and where the line crosses a border set with
+// WithColumnRightBorder or a column group separator, r is drawn in place of
+// the border's characters, with the line continuing through its spaces. '+'
+// suits ASCII borders, and '┼' box-drawing ones. Passing a zero rune restores
+// the default, unconnected separators.
+//
+//	New("Account", "Debit").WithHeaderSeparatorRow('-').WithColumnRightBorder(0, '|').
+//	  WithSeparatorJunction('+').AddRow("cash", 100)
+//	// Output:
+//	// Account  | Debit
+//	// ---------+-------
+//	// cash     | 100
+//
+// WithStyle draws the lines around and between the cells described by style,
+// such as one of the presets StyleASCII, StyleRounded, StyleDouble or
+// StyleMarkdown. While a style is set, it replaces the header separator row,
+// column borders, column group separators and separator junctions, and rows
+// added by AddSeparatorRow are drawn as lines of the style. Line numbers are
+// printed outside of the side borders. Styles look best with WithPadding(1).
+// It has no effect in plain mode. Passing TableStyle{} removes the style.
+//
+//	New("ID", "Name").WithPadding(1).WithStyle(table.StyleRounded).AddRow(1, "foo").Print()
+//	// Output:
+//	// ╭────┬──────╮
+//	// │ ID │ Name │
+//	// ├────┼──────┤
+//	// │ 1  │ foo  │
+//	// ╰────┴──────╯
+//
+// WithColumnHeaderIcon prefixes the header of the column at columnIndex with
+// icon, separated by a space, when the table is printed. The icon is measured
+// with the table's WidthFunc, so a WidthFunc that understands wide characters
+// should be used for emoji. Passing an empty icon removes it.
+//
+//	New("Host", "Latency").WithColumnHeaderIcon(1, "⏱").WithWidthFunc(runewidth.StringWidth)
+//	// Output:
+//	// Host  ⏱ Latency
+//
+// WithZeroPad left-pads the numeric cells in the column at columnIndex with
+// zeros so that their integer part has at least totalDigits digits. Cells that
+// are not numeric are left alone. The padding is applied when the table is
+// printed and is taken into account when sizing the column; the stored values
+// are not modified. A totalDigits value less than or equal to zero disables
+// padding for the column.
+//
+//	New("ID", "Name").WithZeroPad(0, 3).AddRow(7, "foo").Print()
+//	// Output:
+//	// ID   Name
+//	// 007  foo
+//
+// WithColumnDecimalPlaces rounds the numeric cells in the column at columnIndex
+// to places decimal places when the table is printed, so that a column of
+// amounts displays consistently. Rounding is to the nearest value, with halves
+// rounded away from zero, as written in decimal rather than as stored in a
+// float64. Cells that are not numeric are left alone. The rounded value is
+// used to size the column. A negative places removes the rounding.
+//
+//	New("Item", "Cost").WithColumnDecimalPlaces(1, 2).AddRow("pen", 1.005).AddRow("ink", 3)
+//	// Output:
+//	// Item  Cost
+//	// pen   1.01
+//	// ink   3.00
+//
+// WithThousandsSeparator groups the digits of the integer part of numeric
+// cells in the column at columnIndex into thousands, separated by sep, when
+// the table is printed. It is applied after WithColumnDecimalPlaces and
+// WithZeroPad, and the grouped value is used to size the column. The stored
+// values are unchanged, so comparisons such as sorting still see the raw
+// number. Cells that are not plain decimal numbers are left alone. Passing a
+// zero rune removes the separator.
+//
+//	New("City", "Population").WithThousandsSeparator(1, ',').AddRow("Paris", 2102650)
+//	// Output:
+//	// City   Population
+//	// Paris  2,102,650
+//
+// WithSmartAlignment aligns each cell of the column at columnIndex by its
+// content: numbers, including those grouped by WithThousandsSeparator, are
+// right-aligned, and anything else is left-aligned. This suits columns that
+// are mostly numeric but hold the odd label. Alignments given to AddRowAligned
+// take precedence, and the header is not affected. Passing false turns it off
+// again.
+//
+//	New("Item", "Cost").WithSmartAlignment(1, true).AddRow("pen", 1.5).AddRow("ink", 12).AddRow("cap", "free")
+//	// Output:
+//	// Item  Cost
+//	// pen    1.5
+//	// ink     12
+//	// cap   free
+//
+// WithColumnSubfields splits the cells in the column at columnIndex on sep when
+// the table is printed, showing each subfield on its own line within the row.
+// Whitespace around each subfield is trimmed. The column is sized to its
+// longest subfield. Passing an empty sep removes the splitting.
+//
+//	New("Pod", "Labels").WithColumnSubfields(1, ";").AddRow("web", "app=web; tier=frontend")
+//	// Output:
+//	// Pod  Labels
+//	// web  app=web
+//	//      tier=frontend
+//
+// WithUnitColumn lays out the column at columnIndex as a number followed by a
+// unit, such as "12 ms" or "3.4GB", when the table is printed. The numbers are
+// right-aligned so their digits line up, and the units are left-aligned after
+// them, separated by a space. Cells that do not start with a number are left
+// as they are. It has no effect in plain mode. A negative columnIndex is
+// ignored.
+//
+//	New("Op", "Latency").WithUnitColumn(1).AddRow("get", "12 ms").AddRow("put", "1.5 s")
+//	// Output:
+//	// Op   Latency
+//	// get   12 ms
+//	// put  1.5 s
+//
+// WithOutlierWidthCap sizes the column at columnIndex to fit the given
+// percentile of its cell widths, such as 90, rather than its widest cell, so
+// that a few unusually long values do not widen the whole table. The column is
+// never narrower than its header. Cells wider than the column are wrapped onto
+// extra lines within their row, breaking wherever the width runs out. It has
+// no effect in plain mode. A percentile outside of the range (0, 100) removes
+// the cap, and a negative columnIndex is ignored.
+//
+//	New("ID", "Path").WithOutlierWidthCap(1, 50).
+//	  AddRow(1, "/bin").AddRow(2, "/usr").AddRow(3, "/usr/local/share").Print()
+//	// Output:
+//	// ID  Path
+//	// 1   /bin
+//	// 2   /usr
+//	// 3   /usr
+//	//     /loc
+//	//     al/s
+//	//     hare
+//
+// WithMaxColumnWidth caps the width of each column, excluding padding, at the
+// value given in the same position. Cells wider than their column's cap are
+// wrapped onto extra lines within their row, breaking wherever the width runs
+// out, and the other cells of the row are left empty on those lines. A column
+// is never narrower than its header. A cap of 0, or a column without one, is
+// unlimited. It has no effect in plain mode.
+//
+//	New("ID", "Path").WithMaxColumnWidth(0, 6).
+//	  AddRow(1, "/bin").AddRow(2, "/usr/local").Print()
+//	// Output:
+//	// ID  Path
+//	// 1   /bin
+//	// 2   /usr/l
+//	//     ocal
+//
+// WithRunningTotal appends a column titled header whose cells hold the
+// cumulative sum of the numeric values in the sourceColumn, from the first row
+// through the current one. Cells that are not numeric count as zero. The totals
+// are computed when the table is printed, so they always reflect the current
+// rows and their order. The total uses as many decimal places as the most
+// precise value summed so far. Calling it again for the same sourceColumn
+// replaces the header, and an empty header removes the column. A negative
+// sourceColumn is ignored.
+//
+//	New("Date", "Amount").WithRunningTotal(1, "Balance").
+//	  AddRow("05-01", 100).AddRow("05-02", -25.5).AddRow("05-03", 10)
+//	// Output:
+//	// Date   Amount  Balance
+//	// 05-01  100     100
+//	// 05-02  -25.5   74.5
+//	// 05-03  10      84.5
+//
+// WithRowTotalColumn appends a column titled header, after any added by
+// WithRunningTotal, whose cells hold the sum of the numeric values in the
+// given columns of the same row. Cells that are not numeric count as zero,
+// unless WithRowTotalStrict is enabled. The total uses as many decimal places
+// as the most precise value summed. Negative columns are ignored. Calling it
+// again replaces the column, and an empty header removes it.
+//
+//	New("Item", "Q1", "Q2").WithRowTotalColumn("Total", []int{1, 2}).AddRow("pens", 3, 4.5)
+//	// Output:
+//	// Item  Q1  Q2   Total
+//	// pens  3   4.5  7.5
+//
+// WithRowTotalStrict leaves the cell of the WithRowTotalColumn column empty for
+// rows in which any of the summed cells, including an empty one, is not a
+// number, rather than counting such cells as zero.
+//
+// WithColumnTransform sets a TransformFunc that replaces the value of every
+// cell in the column at columnIndex when the table is printed. The transformed
+// value is used both to size the column and as the printed text, so it may
+// safely change the width of the cell. The transform receives the stored value
+// and is applied before any other column options, such as WithZeroPad. Passing
+// nil removes the transform.
+//
+//	New("Name").WithColumnTransform(0, strings.TrimSpace)
+//
+// WithColumnAbbreviations replaces cells in the column at columnIndex that
+// exactly match a key of abbr with its value when the table is printed, to
+// narrow columns of long, repetitive values. Unmatched values are printed as
+// they are, and the stored values are unchanged. Abbreviations are applied
+// after any WithColumnTransform. Passing an empty map removes them.
+//
+//	New("City", "Country").
+//	  WithColumnAbbreviations(1, map[string]string{"United States": "US"}).
+//	  AddRow("Boston", "United States").AddRow("Paris", "France")
+//	// Output:
+//	// City    Country
+//	// Boston  US
+//	// Paris   France
+//
+// WithColumnCase changes the case of the cells in the column at columnIndex
+// to c when the table is printed, such as CaseUpper for consistent codes. The
+// column is sized after the change, so it is safe even for letters whose case
+// forms differ in width. The stored values are unchanged. Passing CaseNone
+// removes it.
+//
+//	New("Name", "Country").WithColumnCase(0, table.CaseTitle).AddRow("ada lovelace", "uk")
+//	// Output:
+//	// Name          Country
+//	// Ada Lovelace  uk
+//
+// WithBoolNormalize rewrites boolean-like cells in the column at columnIndex to
+// trueText or falseText when the table is printed, so inconsistent input such
+// as "TRUE", "1" and "yes" all display the same way. Recognized values are
+// those accepted by strconv.ParseBool along with "yes", "no", "y", "n", "on"
+// and "off" in any case. Other cells are left alone. Passing empty strings for
+// both texts removes the normalization from the column.
+//
+//	New("Name", "Admin").WithBoolNormalize(1, "Yes", "No")
+//
+// WithLineNumbers prepends a column numbering each row, starting at 1, when the
+// table is printed. The numbers are right-aligned and the column is sized to
+// fit the row count. The column is not part of the table's data. Line numbers
+// are disabled by default.
+//
+//	New("foo").WithLineNumbers(true).AddRow("fizz").AddRow("buzz").Print()
+//	// Output:
+//	// #  foo
+//	// 1  fizz
+//	// 2  buzz
+//
+// WithThresholdColoring formats entire rows based on the numeric value in the
+// column at columnIndex. Each cell of a row is passed to the Formatter of the
+// highest Threshold whose Value is less than or equal to the row's value. Rows
+// with a non-numeric value, or a value below every threshold, are left alone.
+// As with other formatters, the Formatter should not change the width of the
+// text. Passing no thresholds removes the coloring.
+//
+//	New("Host", "Errors").WithThresholdColoring(1, []table.Threshold{
+//	  {Value: 0, Formatter: green},
+//	  {Value: 10, Formatter: yellow},
+//	  {Value: 100, Formatter: red},
+//	})
+//
+// WithRowColorCycle formats the cells of each row with one of formatters,
+// cycling through them by row index: the first row uses formatters[0], the
+// second formatters[1], and so on, wrapping around at the end. A nil entry
+// leaves its rows unformatted, so two formatters with one nil produce zebra
+// striping. Rows colored by WithThresholdColoring are not affected. Passing no
+// formatters removes the cycle.
+//
+//	New("foo", "bar").WithRowColorCycle([]table.Formatter{red, yellow, green})
+//
+// WithPlainMode switches the table to a machine-readable output intended for
+// scripts and tools like grep, cut and awk. When enabled, formatters, header
+// icons and the header separator row are ignored, and cells are separated by a
+// single tab instead of being padded to align. Column options that change cell
+// values, such as WithColumnTransform, still apply.
+//
+//	New("foo", "bar").WithPlainMode(true).AddRow("fizz", "buzz").Print()
+//	// Output:
+//	// foo	bar
+//	// fizz	buzz
+//
+// SetColumnWidths sets the width of each column, excluding padding, instead of
+// sizing it to fit its widest cell. By default the widths are minimums, so
+// columns still grow to fit wider cells. With WithExactColumnWidths(true), the
+// widths are respected exactly, and wider cells and headers are cut short to
+// fit. A width of zero, or a column without a width, is sized to fit as usual.
+// Passing nil removes the widths.
+//
+//	New("ID", "Name").SetColumnWidths([]int{4, 3}).WithExactColumnWidths(true).
+//	  AddRow(1, "foobar").Print()
+//	// Output:
+//	// ID    Nam
+//	// 1     foo
+//
+// WithOverflowFootnotes keeps the cells cut short by WithExactColumnWidths
+// readable: each such cell ends with a numbered marker such as "[1]", and the
+// full values are listed by their markers below the table. Headers are cut
+// short without a footnote. It is disabled by default.
+//
+//	New("ID", "Name").SetColumnWidths([]int{2, 6}).WithExactColumnWidths(true).
+//	  WithOverflowFootnotes(true).AddRow(1, "foobarbaz").Print()
+//	// Output:
+//	// ID  Name
+//	// 1   foo[1]
+//	// [1] foobarbaz
+//
+// WithHeaderAtBottom repeats the header, and the header separator row if
+// enabled, after the last row, which helps when reading very tall tables. The
+// repeated header is formatted the same as the one at the top, with the
+// separator placed above it. It has no effect in plain mode.
+//
+//	New("ID", "Name").WithHeaderAtBottom(true).AddRow(1, "foo").Print()
+//	// Output:
+//	// ID  Name
+//	// 1   foo
+//	// ID  Name
+//
+// WithFooterSeparatorRow draws a line of r above the footer added by
+// AddFooter, visually separating it from the rows. If the table has a Style,
+// the Style's lines are drawn instead. A zero rune, the default, removes the
+// line.
+//
+//	New("Item", "Cost").WithFooterSeparatorRow('=').
+//	  AddRow("pen", 1).AddRow("ink", 3).AddFooter("total", 4).Print()
+//	// Output:
+//	// Item   Cost
+//	// pen    1
+//	// ink    3
+//	// ===========
+//	// total  4
+//
+// WithTotalWidth sets the width, in the units of the WidthFunc, that the
+// table is laid out to fill by options such as WithLastColumnFlushRight. When
+// it is zero, the default, the width of the terminal is used if the Writer is
+// a terminal, falling back to the COLUMNS environment variable if the width
+// cannot be read.
+//
+// WithLastColumnFlushRight right-aligns the last column and pushes it against
+// the right edge of the total width, with the extra space added to the column
+// before it. This suits two-column key/value displays such as settings or
+// summaries. The last column's padding, any line numbers and the panel are
+// included in the total width. If the width is unknown, or the table is wider
+// than it, the last column is only right-aligned.
+//
+//	New("Setting", "Value").WithTotalWidth(24).WithLastColumnFlushRight(true).
+//	  AddRow("timeout", "30s").Print()
+//	// Output:
+//	// Setting          Value
+//	// timeout            30s
+//
+// WithPanel draws a box around the table, with title in its top border, in the
+// style of a terminal UI panel. The box fits the table's columns, widening if
+// the title is longer, and assumes formatters do not change the width of the
+// text. It has no effect in plain mode, and an empty title removes the panel.
+//
+//	New("ID", "Name").WithPanel("Users").AddRow(1, "foo").Print()
+//	// Output:
+//	// ┌─ Users ───┐
+//	// │ ID  Name  │
+//	// │ 1   foo   │
+//	// └───────────┘
+//
+// WithLineRenderer sets a LineRenderer used to build every printed line,
+// including the header and header separator row, from its padded cells. This
+// allows for custom borders and separators. A line number cell, if enabled, is
+// passed as the first cell. The renderer replaces the column group separators,
+// and the HeaderFormatter is applied to the rendered header lines as a whole.
+// Passing nil restores the default, which concatenates the cells.
+//
+//	New("ID", "Name").WithLineRenderer(func(cells []string) string {
+//	  return "| " + strings.Join(cells, "| ") + "|"
+//	}).AddRow(1, "foo").Print()
+//	// Output:
+//	// | ID  | Name  |
+//	// | 1   | foo   |
+//
+// WithWrapContinuationMarker prefixes every line after the first of a cell
+// that spans multiple lines, such as one split by WithColumnSubfields or
+// wrapped by WithMaxColumnWidth, with marker. This distinguishes the continued
+// lines of a cell from separate rows. The marker counts towards the column
+// width, and wrapped lines leave room for it. It has no effect in plain mode,
+// and an empty marker removes it.
+//
+//	New("Pod", "Labels").WithColumnSubfields(1, ";").WithWrapContinuationMarker("↪ ").
+//	  AddRow("web", "app=web; tier=frontend")
+//	// Output:
+//	// Pod  Labels
+//	// web  app=web
+//	//      ↪ tier=frontend
+//
+// WithAutoLinkURLs turns http and https URLs found in cells into OSC 8
+// terminal hyperlinks when the table is printed, making them clickable in
+// terminals that support it. The visible text remains the URL. Columns are
+// sized before the escape sequences are added, so alignment is not affected.
+// It has no effect in plain mode and is disabled by default.
+//
+//	New("Name", "Docs").WithAutoLinkURLs(true).AddRow("table", "https://pkg.go.dev/github.com/rodaine/table")
+//
+// WithBoolSymbols controls whether columns of type TypeBool, as reported by
+// Schema, are printed with a green "✓" for true and a red "✗" for false. The
+// symbols are only used when the table's Writer is a terminal, as reported by
+// IsTerminal; otherwise the cells are printed as they are. It has no effect in
+// plain mode. It is disabled by default, as set by DefaultBoolSymbols.
+//
+//	New("Name", "Active").WithBoolSymbols(true)
+//
+// WithNoTruncate stops AddRow, SetRows and the other methods that add rows from
+// dropping the cells of a row that has more values than the table has columns.
+// Instead, the table grows to fit the row by appending columns with empty
+// headers, and the existing rows are padded with empty cells. It is disabled
+// by default.
+//
+//	New("ID").WithNoTruncate(true).AddRow(1, "foo").Print()
+//	// Output:
+//	// ID
+//	// 1   foo
+//
+// WithUniqueHeaders renames duplicated headers so every column can be told
+// apart by name, such as after joining tables that share a column name, or
+// when exporting rows as objects keyed by header. The first column with a
+// given header keeps it, and each later one gets the next free suffix of
+// "_2", "_3", and so on. Headers are renamed when the option is enabled, and
+// again whenever the header changes, such as by PromoteFirstRowToHeader or
+// JoinOn. Empty headers are left as they are. Disabling it does not restore
+// the original headers. It is disabled by default.
+//
+//	New("Name", "Name", "Name").WithUniqueHeaders(true)
+//	// Headers: Name, Name_2, Name_3
+//
+// WithVisibleWhitespace makes leading and trailing whitespace in cell values
+// visible when the table is printed, by replacing each such space or tab with
+// a middle dot (·). This helps spot stray spaces that would otherwise be
+// indistinguishable from padding. Whitespace inside a value, headers, and the
+// output of WithPlainMode are left alone. It is disabled by default.
+//
+//	New("Key", "Value").WithVisibleWhitespace(true).AddRow("name", " alice  ").Print()
+//	// Output:
+//	// Key   Value
+//	// name  ·alice··
+//
+// Schema describes the shape of the table without its data, returning the
+// header, inferred ColumnType and width of each column. The width is that of
+// the widest cell (or header) as it would be printed, excluding padding.
+//
+//	for _, col := range tbl.Schema() {
+//	  fmt.Println(col.Header, col.Type, col.Width)
+//	}
+//
+// ColumnIndex returns the index of the first column with the header name, and
+// whether there is one. Headers are matched exactly, without the icons added
+// by WithColumnHeaderIcon. The columns added by options such as
+// WithRunningTotal are not included.
+//
+//	if i, ok := tbl.ColumnIndex("Price"); ok {
+//	  tbl.WithColumnDecimalPlaces(i, 2)
+//	}
+//
+// NumRows returns the number of rows in the table, including those added by
+// AddSeparatorRow, but not the header or footer. NumColumns returns the number
+// of columns in the header, without the columns added by options such as
+// WithRunningTotal.
+//
+//	assert.Equal(t, 3, tbl.NumRows())
+//
+// GetCell returns the value of a single cell, identified by the indexes of its
+// row, in the order rows were added, and its column, as the table holds it
+// before any column options are applied. A cell missing from a row shorter
+// than the header is empty. An error is returned if either index is out of
+// range, or if the row was added by AddSeparatorRow.
+//
+//	name, err := tbl.GetCell(0, 1)
+//
+// EstimateWidths returns the width each column would need to fit both its
+// header and the cells of sampleRows, without adding the rows to the table.
+// Column options such as WithColumnTransform are applied to the sample, and
+// cells beyond the number of columns are ignored. As with Schema, the widths
+// exclude padding.
+//
+//	widths := tbl.EstimateWidths(firstHundredRows)
+//
+// WidestRow reports which row would be the widest if every column were only as
+// wide as that row's own cells, and that width, to help find the values that
+// make a table wider than expected. The width includes the padding and any
+// column borders and group separators, but not line numbers or the panel.
+// Rows are indexed in the order they were added, and rows added by
+// AddSeparatorRow are ignored. For a table without rows, it returns -1 and 0.
+//
+//	i, w := tbl.WidestRow()
+//	fmt.Printf("row %d is %d wide\n", i, w)
+//
+// TemplateData returns the table's headers, rows and column widths as a
+// TemplateData value, allowing the table to be laid out with a custom
+// text/template while reusing the package's width calculations.
+//
+// WithExportHeaderComment sets a comment, such as when and how the data was
+// generated, written before the table by the text-based exporters in the
+// syntax of their format. ExportOrg writes each line of the comment prefixed
+// with "# ", ExportMarkdown writes it as a blockquote followed by a blank
+// line, and ExportHTML writes it as an HTML comment. ExportJSONNested,
+// ExportJSONArray and ExportClipboardTSV, whose formats have no comments,
+// ignore it. An empty comment removes it.
+//
+//	tbl.WithExportHeaderComment(fmt.Sprintf("Generated %s, %d rows", time.Now().Format(time.RFC3339), n))
+//
+// ExportOrg writes the table to w as an Emacs org-mode table, with a separator
+// line after the header. Pipes in cell values are escaped as \vert{}. The
+// header and separator line of a table created by NewHeaderless are skipped.
+// The table's column options and formatters are not applied to the exported
+// data.
+//
+//	| ID | Name   |
+//	|----+--------|
+//	| 1  | Foobar |
+//
+// ExportJSONNested writes the rows to the table's Writer as JSON, grouped into
+// nested objects by the values of keyColumns: the first key column forms the
+// outermost level, the next one the level within it, and so on. Each group of
+// the last key column holds an array of the rows sharing those keys, so rows
+// with equal keys are never lost. Each row is an object mapping the headers of
+// the remaining columns to their values; if headers are duplicated, the last
+// such column wins. A table created by NewHeaderless uses the column indexes,
+// such as "0", in place of headers. With no key columns, the rows are written
+// as a flat array. An error is returned if a key column is out of range or
+// repeated.
+//
+//	New("Region", "Country", "City").
+//	  AddRow("EU", "FR", "Paris").
+//	  AddRow("EU", "FR", "Lyon").
+//	  ExportJSONNested([]int{0, 1})
+//	// Output:
+//	// {"EU":{"FR":[{"City":"Paris"},{"City":"Lyon"}]}}
+//
+// ExportClipboardTSV writes the header and rows to the table's Writer as
+// tab-separated values suited to pasting into a spreadsheet such as Google
+// Sheets. Cells are written as they are, except those containing a tab,
+// newline or double quote, which are wrapped in double quotes with any double
+// quotes inside doubled, so they paste into a single cell. No byte order mark
+// is written. Rows added by AddSeparatorRow are skipped, as is the header of a
+// table created by NewHeaderless.
+//
+//	tbl.WithWriter(clipboard).ExportClipboardTSV()
+//
+// Records returns a copy of the header followed by the rows, each with one
+// value per column, in the shape expected by encoding/csv and other libraries
+// working with raw records. Rows added by AddSeparatorRow are skipped, as is
+// the header of a table created by NewHeaderless. The table's column options
+// and formatters are not applied.
+//
+//	csv.NewWriter(os.Stdout).WriteAll(tbl.Records())
+//
+// ExportJSONArray streams the rows to the table's Writer as a JSON array, with
+// one object per row mapping each header to the row's value, in row order. Like
+// ExportJSONNested, a table created by NewHeaderless is keyed by column index.
+// Rows are encoded and written one at a time, flushing the Writer after each
+// if it has a Flush() error method (such as a *bufio.Writer), so the memory
+// used does not grow with the size of the table.
+//
+//	tbl.WithWriter(bufio.NewWriter(os.Stdout)).ExportJSONArray()
+//	// Output:
+//	// [
+//	// {"ID":"1","Name":"Foobar"},
+//	// {"ID":"2","Name":"Fizzbuzz"}
+//	// ]
+//
+// ExportMarkdown writes the table to its Writer as a GitHub-flavored Markdown
+// table, with a separator line after the header. Pipes in cell values are
+// escaped as \| and newlines are replaced with <br>, so each row stays on a
+// single line. Rows added by AddSeparatorRow are skipped, as are the header
+// and separator line of a table created by NewHeaderless. The table's column
+// options and formatters are not applied to the exported data.
+//
+//	| ID | Name   |
+//	|----|--------|
+//	| 1  | Foobar |
+//
+// ExportHTML writes the table to its Writer as an HTML <table>, with the
+// header in a <thead> of <th> cells and each row in the <tbody> as a <tr> of
+// <td> cells. Header and cell text is escaped with html.EscapeString, and
+// newlines are replaced with <br>. Rows added by AddSeparatorRow are skipped,
+// and a table created by NewHeaderless has no <thead>. The table's column
+// options and formatters are not applied to the exported data.
+//
+//	<table>
+//	  <thead>
+//	    <tr><th>ID</th><th>Name</th></tr>
+//	  </thead>
+//	  <tbody>
+//	    <tr><td>1</td><td>Foobar</td></tr>
+//	  </tbody>
+//	</table>
+//
+// IsTerminal reports whether the table's Writer is a terminal, which is useful
+// for deciding whether to apply ANSI formatters. Only writers that expose an
+// Fd method, such as *os.File, can be detected; any other writer is assumed
+// not to be a terminal. Other character devices, such as /dev/null, are not
+// terminals.
+//
+//	if tbl.IsTerminal() {
+//	  tbl.WithHeaderFormatter(color.New(color.FgGreen).SprintfFunc())
+//	}
+//
+// WithUnicodeNormalization converts the values of rows added afterwards to the
+// Unicode normalization form, such as norm.NFC, so that values that are
+// canonically equivalent but were encoded differently have the same width and
+// compare equal. This matters for accented text gathered from different
+// sources. The form is defined by golang.org/x/text/unicode/norm.
+//
+//	New("Name").WithUnicodeNormalization(norm.NFC).AddRow("Zoe\u0308")
+//
 // AddRow adds another row of data to the table. Any values can be passed in and
 // will be output as its string representation as described in the fmt standard
 // package. Rows can have less cells than the total number of columns in the table;
@@ -112,33 +831,321 @@ type WidthFunc func(string) int
 //	// 2006-01-02 15:04:05.0 -0700 MST
 //	// 1                                2
 //
+// WithValueStringer sets the StringerFunc used to convert the values passed to
+// AddRow, AddRowAligned and AddTreeRow to the text of their cells, such as to
+// format every time.Time with the same layout. Values it does not handle, and
+// all values when it is nil, the default, are converted with fmt.Sprint. The
+// stringer is applied as rows are added, so it does not change existing rows.
+//
+//	New("Name", "Joined").WithValueStringer(dates).AddRow("alice", time.Now())
+//
+// AddRowAligned adds a row like AddRow, aligning each cell within its column
+// according to the corresponding entry of aligns. Cells without an entry are
+// aligned like the rest of their column. This allows individual cells, such as
+// a placeholder dash, to be positioned differently from the rest of their
+// column.
+//
+//	New("Name", "Score").
+//	  AddRow("alice", 42).
+//	  AddRowAligned([]table.Alignment{table.AlignLeft, table.AlignCenter}, "bob", "-").
+//	  Print()
+//	// Output:
+//	// Name   Score
+//	// alice  42
+//	// bob      -
+//
+// AddRowf formats a row according to a format specifier, as with fmt.Sprintf,
+// and adds it to the table. The formatted text is split into cells on tab
+// characters, in the style of text/tabwriter. Otherwise it behaves like AddRow.
+//
+//	New("ID", "Name", "Cost").AddRowf("%03d\t%s\t$%.2f", 7, "Foobar", 1.5).Print()
+//	// Output:
+//	// ID   Name    Cost
+//	// 007  Foobar  $1.50
+//
+// AddTreeRow adds a row like AddRow, indenting its first cell by depth levels
+// to render a tree or outline. Each level is indented with the string set by
+// WithTreeIndent, which defaults to DefaultTreeIndent. The indentation becomes
+// part of the cell's value, so it counts towards the column's width, and
+// changing the indent only affects rows added afterwards.
+//
+//	New("Package", "Version").WithTreeIndent("└─ ").
+//	  AddTreeRow(0, "app", "1.0").
+//	  AddTreeRow(1, "lib", "2.3").
+//	  Print()
+//	// Output:
+//	// Package  Version
+//	// app      1.0
+//	// └─ lib   2.3
+//
+// PromoteFirstRowToHeader removes the first row of the table and uses its
+// values as the header, growing the table if the row is wider than the current
+// header. Missing headers are left empty. It is mostly used with tables
+// created by NewHeaderless, which print their header once it has been
+// promoted. It has no effect on a table without rows.
+//
+// SetCell replaces the value of a single cell, identified by the indexes of its
+// row, in the order rows were added, and its column. Rows shorter than the
+// header are padded as needed. An error is returned if either index is out of
+// range, or if the row was added by AddSeparatorRow.
+//
+//	tbl.SetCell(2, 1, "corrected")
+//
+// UpdateCell replaces the value of a single cell like SetCell, converting val
+// to text as AddRow does. Like SetCell, it operates on the text the table
+// already holds, so newlines in val give a multi-line cell rather than extra
+// rows. The table is unchanged if an error is returned.
+//
+//	tbl.UpdateCell(2, 1, 42)
+//
+// RemoveRow removes the row at index, in the order rows were added, including
+// rows added by AddSeparatorRow. The rows after it move up by one. An error is
+// returned, leaving the table unchanged, if index is out of range.
+//
+//	tbl.RemoveRow(0)
+//
+// Normalize makes the table rectangular, padding every row shorter than the
+// header with empty cells and truncating every row that is longer, so each row
+// holds exactly one value per column. Rows added by AddSeparatorRow are left
+// alone. This guarantees the shape of the rows for exports and any other
+// processing of the data.
+//
+//	tbl.Normalize().Records()
+//
+// AddSeparatorRow adds a horizontal rule spanning the full width of the table,
+// such as before a subtotal. The rule is drawn with the rune set by
+// WithHeaderSeparatorRow, or '-' if there is none. It is skipped in plain mode,
+// in exports, and when splitting or joining tables, and is not counted by
+// WithLineNumbers.
+//
+//	New("Item", "Cost").AddRow("pen", 1).AddRow("ink", 3).AddSeparatorRow().AddRow("total", 4).Print()
+//	// Output:
+//	// Item   Cost
+//	// pen    1
+//	// ink    3
+//	// -----------
+//	// total  4
+//
+// AddFooter sets the footer of the table, such as a row of totals, printed
+// after all of the other rows. Its values are converted and fit to the columns
+// exactly as those of AddRow, except that newlines give multi-line cells, and
+// its cells are counted when sizing the columns. Calling it again replaces the
+// footer. The footer is printed on the last page of PrintPage, is not counted
+// by WithLineNumbers, and is not exported.
+//
+//	New("Item", "Cost").AddRow("pen", 1).AddRow("ink", 3).AddFooter("total", 4)
+//
+// JoinOn combines the table with other, matching the values in column thisKey
+// against those in column otherKey of other. The resulting table has this
+// table's columns followed by the non-key columns of other, and inherits this
+// table's configuration. A row is emitted for every matching pair; with
+// LeftJoin, rows that have no match in other are kept with empty joined cells.
+// An error is returned if either key is out of range.
+//
+//	joined, err := users.JoinOn(orders, 0, 1, table.LeftJoin)
+//
+// SplitByColumn partitions the rows into separate tables keyed by the distinct
+// values of the column at columnIndex. Each table has the same header and
+// configuration as the original, and keeps its rows in their original order.
+// A nil map is returned if columnIndex is out of range.
+//
+//	for region, tbl := range sales.SplitByColumn(0) {
+//	  fmt.Println(region)
+//	  tbl.Print()
+//	}
+//
+// MarshalState serializes the table's header, rows and settings to JSON, so
+// that an expensive table can be cached and printed again later with
+// UnmarshalState. Settings that are functions, such as formatters, and the
+// Writer cannot be serialized; see UnmarshalState for what survives the
+// round-trip.
+//
+// Equal reports whether other has the same header, rows and footer as the
+// table, ignoring all configuration such as the writer and formatters. Missing
+// trailing cells are treated as empty, but a separator row never equals a row
+// of cells, even an empty one. Diff describes each mismatched header, row or
+// footer cell on its own line, returning an empty string if the tables are
+// Equal. Both are intended for use in tests.
+//
+//	if diff := got.Diff(want); diff != "" {
+//	  t.Errorf("table mismatch:\n%s", diff)
+//	}
+//
+// PrintErr writes the table to the Writer like Print, returning the error from
+// the Writer, if any, such as when writing to a closed pipe. The table is laid
+// out in memory and written in a single call, so a failing Writer never costs
+// the work of writing the remaining rows.
+//
+//	if err := tbl.PrintErr(); err != nil {
+//	  return fmt.Errorf("printing results: %w", err)
+//	}
+//
+// Render returns the table exactly as Print would write it, rather than
+// writing it to the Writer. It is useful for embedding the table in other
+// output or comparing it in tests.
+//
+//	log.Printf("results:\n%s", tbl.Render())
+//
+// Lines returns each line of the table as Print would write it, such as the
+// header, header separator and rows, without the trailing newlines. This is
+// convenient for paginating or filtering the output.
+//
+//	for _, line := range tbl.Lines()[1:] {
+//	  fmt.Println(line)
+//	}
+//
+// PrintFirstColumns prints the table like Print, but only with its first n
+// columns, as a quick preview of a wide table. A final column headed with the
+// number of omitted columns, such as "(+3)", is added if any are left out.
+// Columns added by WithRunningTotal and WithRowTotalColumn count as omitted
+// columns.
+//
+//	New("A", "B", "C", "D").AddRow(1, 2, 3, 4).PrintFirstColumns(2)
+//	// Output:
+//	// A  B  (+2)
+//	// 1  2
+//
+// PrintPage prints the table like Print, but only with the rows from offset up
+// to offset+pageSize, clamped to the rows that exist. The columns are sized to
+// fit every row, and line numbers and running totals count from the first
+// row, so all pages line up consistently. The stored rows are not changed.
+// PageCount returns the number of pages of pageSize rows needed to print every
+// row, or zero if pageSize is not positive.
+//
+//	for page := 0; page < tbl.PageCount(20); page++ {
+//	  tbl.PrintPage(page*20, 20)
+//	}
+//
+// ClearPrevious moves the cursor up over the output of the last call to Print,
+// PrintFirstColumns or PrintPage and clears it, using ANSI escape codes, so
+// that the next Print overwrites it. This is a simple way to update a table in
+// place on a terminal; LiveWriter avoids flicker by only redrawing the lines
+// that change. It does nothing if the table has not been printed since the
+// last clear.
+//
+//	for range ticker.C {
+//	  tbl.ClearPrevious()
+//	  tbl.SetRows(poll()).Print()
+//	}
+//
 // Print writes the string representation of the table to the provided writer.
+// To repeatedly redraw a table in place on a terminal, see LiveWriter.
 // Print can be called multiple times, even after subsequent mutations of the
 // provided data. The output is always preceded and followed by a new line.
 type Table interface {
 	WithHeaderFormatter(f Formatter) Table
 	WithFirstColumnFormatter(f Formatter) Table
+	WithColumnFormatter(columnIndex int, f Formatter) Table
 	WithPadding(p int) Table
+	WithColumnAlignment(alignments ...Alignment) Table
 	WithWriter(w io.Writer) Table
+	AddWriter(w io.Writer) Table
 	WithWidthFunc(f WidthFunc) Table
 	WithHeaderSeparatorRow(r rune) Table
+	WithColumnHeaderSeparatorRune(columnIndex int, r rune) Table
+	WithColumnGroupBoundaries(afterColumns []int, sep string) Table
+	WithColumnRightBorder(columnIndex int, r rune) Table
+	WithSeparatorJunction(r rune) Table
+	WithStyle(style TableStyle) Table
+	WithColumnHeaderIcon(columnIndex int, icon string) Table
+	WithZeroPad(columnIndex, totalDigits int) Table
+	WithColumnDecimalPlaces(columnIndex, places int) Table
+	WithThousandsSeparator(columnIndex int, sep rune) Table
+	WithSmartAlignment(columnIndex int, enabled bool) Table
+	WithColumnSubfields(columnIndex int, sep string) Table
+	WithUnitColumn(columnIndex int) Table
+	WithOutlierWidthCap(columnIndex int, percentile float64) Table
+	WithMaxColumnWidth(cols ...int) Table
+	WithRunningTotal(sourceColumn int, header string) Table
+	WithRowTotalColumn(header string, columns []int) Table
+	WithRowTotalStrict(strict bool) Table
+	WithColumnTransform(columnIndex int, f TransformFunc) Table
+	WithColumnAbbreviations(columnIndex int, abbr map[string]string) Table
+	WithColumnCase(columnIndex int, c CaseMode) Table
+	WithBoolNormalize(columnIndex int, trueText, falseText string) Table
+	WithLineNumbers(enabled bool) Table
+	WithThresholdColoring(columnIndex int, thresholds []Threshold) Table
+	WithRowColorCycle(formatters []Formatter) Table
+	WithPlainMode(plain bool) Table
+	WithBoolSymbols(enabled bool) Table
+	WithNoTruncate(noTruncate bool) Table
+	WithUniqueHeaders(unique bool) Table
+	WithVisibleWhitespace(visible bool) Table
+	WithAutoLinkURLs(enabled bool) Table
+	WithWrapContinuationMarker(marker string) Table
+	WithLineRenderer(r LineRenderer) Table
+	WithPanel(title string) Table
+	WithHeaderAtBottom(enabled bool) Table
+	WithFooterSeparatorRow(r rune) Table
+	WithTotalWidth(width int) Table
+	WithLastColumnFlushRight(flush bool) Table
+	WithExactColumnWidths(exact bool) Table
+	WithOverflowFootnotes(enabled bool) Table
+	WithTreeIndent(indent string) Table
+	WithUnicodeNormalization(form norm.Form) Table
+	WithValueStringer(f StringerFunc) Table
 
 	AddRow(vals ...interface{}) Table
+	AddRowAligned(aligns []Alignment, vals ...interface{}) Table
+	AddRowf(format string, args ...interface{}) Table
+	AddTreeRow(depth int, vals ...interface{}) Table
+	AddSeparatorRow() Table
+	AddFooter(vals ...interface{}) Table
+	PromoteFirstRowToHeader() Table
+	Normalize() Table
 	SetRows(rows [][]string) Table
+	SetCell(row, col int, value string) error
+	UpdateCell(row, col int, val interface{}) error
+	RemoveRow(index int) error
+	SetColumnWidths(widths []int) Table
+	JoinOn(other Table, thisKey, otherKey int, how JoinType) (Table, error)
+	SplitByColumn(columnIndex int) map[string]Table
+	MarshalState() ([]byte, error)
+	Equal(other Table) bool
+	Diff(other Table) string
+	Schema() []ColumnSchema
+	ColumnIndex(name string) (int, bool)
+	NumRows() int
+	NumColumns() int
+	GetCell(row, col int) (string, error)
+	EstimateWidths(sampleRows [][]string) []int
+	WidestRow() (index int, width int)
+	TemplateData() TemplateData
+	WithExportHeaderComment(comment string) Table
+	ExportOrg(w io.Writer) error
+	ExportJSONNested(keyColumns []int) error
+	ExportJSONArray() error
+	ExportClipboardTSV() error
+	ExportMarkdown() error
+	ExportHTML() error
+	Records() [][]string
+	IsTerminal() bool
+	Render() string
+	Lines() []string
+	PrintFirstColumns(n int)
+	PrintPage(offset, pageSize int)
+	PageCount(pageSize int) int
+	ClearPrevious()
 	Print()
+	PrintErr() error
 }
 
 // New creates a Table instance with the specified header(s) provided. The number
-// of columns is fixed at this point to len(columnHeaders) and the defined defaults
-// are set on the instance.
+// of columns is fixed at this point to len(columnHeaders), unless WithNoTruncate
+// is enabled, and the defined defaults are set on the instance.
 func New(columnHeaders ...interface{}) Table {
-	t := table{header: make([]string, len(columnHeaders))}
+	t := table{
+		mu:     new(sync.Mutex),
+		header: make([]string, len(columnHeaders)),
+	}
 
 	t.WithPadding(DefaultPadding)
 	t.WithWriter(DefaultWriter)
 	t.WithHeaderFormatter(DefaultHeaderFormatter)
 	t.WithFirstColumnFormatter(DefaultFirstColumnFormatter)
 	t.WithWidthFunc(DefaultWidthFunc)
+	t.WithTreeIndent(DefaultTreeIndent)
+	t.WithBoolSymbols(DefaultBoolSymbols)
 
 	for i, col := range columnHeaders {
 		t.header[i] = fmt.Sprint(col)
@@ -147,176 +1154,2343 @@ func New(columnHeaders ...interface{}) Table {
 	return &t
 }
 
+// AlignWidths lines up the columns of tables that are printed one after
+// another. Each column is widened to the widest cell, including padding, in
+// that column across all of the tables. The widths are applied as if set with
+// SetColumnWidths, so unless exact widths are enabled, a table that later
+// receives wider cells still fits them. Tables not created by New are ignored.
+//
+//	table.AlignWidths(summary, details)
+//	summary.Print()
+//	details.Print()
+func AlignWidths(tables ...Table) {
+	var widths []int
+	for _, tbl := range tables {
+		t, ok := tbl.(*table)
+		if !ok {
+			continue
+		}
+
+		t.mu.Lock()
+		t.columnWidths = nil
+		t.measureWidths(t.displayRows())
+		for i, w := range t.widths {
+			if i < len(widths) {
+				widths[i] = max(widths[i], w)
+			} else {
+				widths = append(widths, w)
+			}
+		}
+		t.mu.Unlock()
+	}
+
+	for _, tbl := range tables {
+		if t, ok := tbl.(*table); ok {
+			t.mu.Lock()
+			t.columnWidths = make([]int, len(widths))
+			for i, w := range widths {
+				t.columnWidths[i] = max(w-t.Padding, 0)
+			}
+			t.mu.Unlock()
+		}
+	}
+}
+
+// NewHeaderless creates a Table without a header, which grows to fit the rows
+// added to it as if WithNoTruncate were enabled. No header is printed until
+// one is set with PromoteFirstRowToHeader. This is useful when the header
+// arrives along with the data, such as when reading a CSV file.
+//
+//	tbl := table.NewHeaderless()
+//	for _, record := range records {
+//	  tbl.AddRow(record...)
+//	}
+//	tbl.PromoteFirstRowToHeader().Print()
+func NewHeaderless() Table {
+	t := New().(*table)
+	t.NoTruncate = true
+	t.headerless = true
+	return t
+}
+
 type table struct {
+	// mu guards all of the fields below. It is a pointer so tables can be
+	// copied by value; copies must be given their own mutex.
+	mu *sync.Mutex
+
 	FirstColumnFormatter Formatter
+	ColumnFormatters     map[int]Formatter
 	HeaderFormatter      Formatter
 	Padding              int
 	Writer               io.Writer
 	Width                WidthFunc
 	HeaderSeparatorRune  rune
+	FooterSeparatorRune  rune
+	ColumnSeparatorRunes map[int]rune
+	GroupBoundaries      map[int]bool
+	RightBorders         map[int]rune
+	SeparatorJunction    rune
+	Style                *TableStyle
+	GroupSeparator       string
+	HeaderIcons          map[int]string
+	ZeroPad              map[int]int
+	DecimalPlaces        map[int]int
+	ThousandsSeparators  map[int]rune
+	SmartAligns          map[int]bool
+	Subfields            map[int]string
+	UnitColumns          map[int]bool
+	OutlierCaps          map[int]float64
+	RunningTotals        []runningTotal
+	RowTotal             *rowTotal
+	RowTotalStrict       bool
+	Transforms           map[int]TransformFunc
+	Abbreviations        map[int]map[string]string
+	Cases                map[int]CaseMode
+	BoolTexts            map[int]boolTexts
+	LineNumbers          bool
+	PlainMode            bool
+	BoolSymbols          bool
+	NoTruncate           bool
+	UniqueHeaders        bool
+	VisibleWhitespace    bool
+	AutoLinkURLs         bool
+	ContinuationMarker   string
+	LineRenderer         LineRenderer
+	ValueStringer        StringerFunc
+	PanelTitle           string
+	ExportComment        string
+	HeaderAtBottom       bool
+	TotalWidth           int
+	LastColumnFlushRight bool
+	ExactWidths          bool
+	OverflowFootnotes    bool
+	TreeIndent           string
+	NormalizeUnicode     bool
+	NormalizationForm    norm.Form
+	ThresholdColumn      int
+	Thresholds           []Threshold
+	RowColorCycle        []Formatter
 
-	header []string
-	rows   [][]string
-	widths []int
+	header       []string
+	rows         [][]string
+	footer       []string
+	cellAligns   [][]Alignment
+	widths       []int
+	columnWidths []int
+	columnAligns []Alignment
+	maxWidths    []int
+	numberWidth  int
+	headerless   bool
+	printedLines int
+	symbolCols   map[int]bool
+	footnotes    []string
+	fullRows     [][]string
+}
+
+// withConfig creates an empty table with the provided header that shares all
+// of t's configuration. The caller must hold t.mu.
+func (t *table) withConfig(header []string) *table {
+	out := *t
+	out.mu = new(sync.Mutex)
+	out.header = header
+	out.rows = nil
+	out.footer = nil
+	out.cellAligns = nil
+	out.widths = nil
+	out.columnWidths = nil
+	out.printedLines = 0
+	out.footnotes = nil
+	out.fullRows = nil
+	out.ZeroPad = copyIntMap(t.ZeroPad)
+	out.DecimalPlaces = copyIntMap(t.DecimalPlaces)
+	out.HeaderIcons = copyStringMap(t.HeaderIcons)
+	out.Subfields = copyStringMap(t.Subfields)
+	out.UnitColumns = make(map[int]bool, len(t.UnitColumns))
+	for k, v := range t.UnitColumns {
+		out.UnitColumns[k] = v
+	}
+	out.OutlierCaps = make(map[int]float64, len(t.OutlierCaps))
+	for k, v := range t.OutlierCaps {
+		out.OutlierCaps[k] = v
+	}
+	out.GroupBoundaries = make(map[int]bool, len(t.GroupBoundaries))
+	for k, v := range t.GroupBoundaries {
+		out.GroupBoundaries[k] = v
+	}
+	out.RightBorders = make(map[int]rune, len(t.RightBorders))
+	for k, v := range t.RightBorders {
+		out.RightBorders[k] = v
+	}
+	out.SmartAligns = make(map[int]bool, len(t.SmartAligns))
+	for k, v := range t.SmartAligns {
+		out.SmartAligns[k] = v
+	}
+	out.ThousandsSeparators = make(map[int]rune, len(t.ThousandsSeparators))
+	for k, v := range t.ThousandsSeparators {
+		out.ThousandsSeparators[k] = v
+	}
+	out.ColumnSeparatorRunes = make(map[int]rune, len(t.ColumnSeparatorRunes))
+	for k, v := range t.ColumnSeparatorRunes {
+		out.ColumnSeparatorRunes[k] = v
+	}
+	out.Abbreviations = make(map[int]map[string]string, len(t.Abbreviations))
+	for k, v := range t.Abbreviations {
+		out.Abbreviations[k] = v
+	}
+	out.Cases = make(map[int]CaseMode, len(t.Cases))
+	for k, v := range t.Cases {
+		out.Cases[k] = v
+	}
+	out.BoolTexts = make(map[int]boolTexts, len(t.BoolTexts))
+	for k, v := range t.BoolTexts {
+		out.BoolTexts[k] = v
+	}
+	out.RunningTotals = append([]runningTotal(nil), t.RunningTotals...)
+	if t.RowTotal != nil {
+		out.RowTotal = &rowTotal{Header: t.RowTotal.Header, Columns: append([]int(nil), t.RowTotal.Columns...)}
+	}
+	out.Thresholds = append([]Threshold(nil), t.Thresholds...)
+	out.RowColorCycle = append([]Formatter(nil), t.RowColorCycle...)
+	out.columnAligns = append([]Alignment(nil), t.columnAligns...)
+	out.maxWidths = append([]int(nil), t.maxWidths...)
+	out.ColumnFormatters = make(map[int]Formatter, len(t.ColumnFormatters))
+	for k, v := range t.ColumnFormatters {
+		out.ColumnFormatters[k] = v
+	}
+	out.Transforms = make(map[int]TransformFunc, len(t.Transforms))
+	for k, v := range t.Transforms {
+		out.Transforms[k] = v
+	}
+	out.uniqueHeaders()
+	return &out
 }
 
 func (t *table) WithHeaderFormatter(f Formatter) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
 	t.HeaderFormatter = f
 	return t
 }
 
 func (t *table) WithHeaderSeparatorRow(r rune) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
 	t.HeaderSeparatorRune = r
 	return t
 }
 
-func (t *table) WithFirstColumnFormatter(f Formatter) Table {
-	t.FirstColumnFormatter = f
-	return t
+func (t *table) WithColumnHeaderSeparatorRune(columnIndex int, r rune) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if r == 0 {
+		delete(t.ColumnSeparatorRunes, columnIndex)
+		return t
+	}
+
+	if t.ColumnSeparatorRunes == nil {
+		t.ColumnSeparatorRunes = make(map[int]rune)
+	}
+	t.ColumnSeparatorRunes[columnIndex] = r
+	return t
+}
+
+func (t *table) WithColumnGroupBoundaries(afterColumns []int, sep string) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.GroupBoundaries = make(map[int]bool, len(afterColumns))
+	for _, col := range afterColumns {
+		t.GroupBoundaries[col] = true
+	}
+	t.GroupSeparator = sep
+	return t
+}
+
+func (t *table) WithSeparatorJunction(r rune) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.SeparatorJunction = r
+	return t
+}
+
+func (t *table) WithColumnRightBorder(columnIndex int, r rune) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if r == 0 {
+		delete(t.RightBorders, columnIndex)
+		return t
+	}
+
+	if t.RightBorders == nil {
+		t.RightBorders = make(map[int]rune)
+	}
+	t.RightBorders[columnIndex] = r
+	return t
+}
+
+func (t *table) WithColumnHeaderIcon(columnIndex int, icon string) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if icon == "" {
+		delete(t.HeaderIcons, columnIndex)
+		return t
+	}
+
+	if t.HeaderIcons == nil {
+		t.HeaderIcons = make(map[int]string)
+	}
+	t.HeaderIcons[columnIndex] = icon
+	return t
+}
+
+func (t *table) WithFirstColumnFormatter(f Formatter) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.FirstColumnFormatter = f
+	return t
+}
+
+func (t *table) WithColumnFormatter(columnIndex int, f Formatter) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if columnIndex < 0 {
+		return t
+	}
+
+	if f == nil {
+		delete(t.ColumnFormatters, columnIndex)
+		return t
+	}
+
+	if t.ColumnFormatters == nil {
+		t.ColumnFormatters = make(map[int]Formatter)
+	}
+	t.ColumnFormatters[columnIndex] = f
+	return t
+}
+
+func (t *table) WithPadding(p int) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if p < 0 {
+		p = 0
+	}
+
+	t.Padding = p
+	return t
+}
+
+func (t *table) WithColumnAlignment(alignments ...Alignment) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.columnAligns = append([]Alignment(nil), alignments...)
+	return t
+}
+
+func (t *table) WithWriter(w io.Writer) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if w == nil {
+		w = os.Stdout
+	}
+
+	t.Writer = w
+	return t
+}
+
+func (t *table) AddWriter(w io.Writer) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if w != nil {
+		t.Writer = io.MultiWriter(t.Writer, w)
+	}
+	return t
+}
+
+func (t *table) WithWidthFunc(f WidthFunc) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.Width = f
+	return t
+}
+
+func (t *table) WithZeroPad(columnIndex, totalDigits int) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if totalDigits <= 0 {
+		delete(t.ZeroPad, columnIndex)
+		return t
+	}
+
+	if t.ZeroPad == nil {
+		t.ZeroPad = make(map[int]int)
+	}
+	t.ZeroPad[columnIndex] = totalDigits
+	return t
+}
+
+func (t *table) WithColumnDecimalPlaces(columnIndex, places int) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if places < 0 {
+		delete(t.DecimalPlaces, columnIndex)
+		return t
+	}
+
+	if t.DecimalPlaces == nil {
+		t.DecimalPlaces = make(map[int]int)
+	}
+	t.DecimalPlaces[columnIndex] = places
+	return t
+}
+
+func (t *table) WithThousandsSeparator(columnIndex int, sep rune) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if sep == 0 {
+		delete(t.ThousandsSeparators, columnIndex)
+		return t
+	}
+
+	if t.ThousandsSeparators == nil {
+		t.ThousandsSeparators = make(map[int]rune)
+	}
+	t.ThousandsSeparators[columnIndex] = sep
+	return t
+}
+
+func (t *table) WithSmartAlignment(columnIndex int, enabled bool) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !enabled {
+		delete(t.SmartAligns, columnIndex)
+		return t
+	}
+
+	if t.SmartAligns == nil {
+		t.SmartAligns = make(map[int]bool)
+	}
+	t.SmartAligns[columnIndex] = true
+	return t
+}
+
+func (t *table) WithColumnSubfields(columnIndex int, sep string) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if sep == "" {
+		delete(t.Subfields, columnIndex)
+		return t
+	}
+
+	if t.Subfields == nil {
+		t.Subfields = make(map[int]string)
+	}
+	t.Subfields[columnIndex] = sep
+	return t
+}
+
+func (t *table) WithUnitColumn(columnIndex int) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if columnIndex < 0 {
+		return t
+	}
+
+	if t.UnitColumns == nil {
+		t.UnitColumns = make(map[int]bool)
+	}
+	t.UnitColumns[columnIndex] = true
+	return t
+}
+
+func (t *table) WithOutlierWidthCap(columnIndex int, percentile float64) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if columnIndex < 0 {
+		return t
+	}
+
+	if percentile <= 0 || percentile >= 100 {
+		delete(t.OutlierCaps, columnIndex)
+		return t
+	}
+
+	if t.OutlierCaps == nil {
+		t.OutlierCaps = make(map[int]float64)
+	}
+	t.OutlierCaps[columnIndex] = percentile
+	return t
+}
+
+func (t *table) WithMaxColumnWidth(cols ...int) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.maxWidths = append([]int(nil), cols...)
+	return t
+}
+
+func (t *table) WithRunningTotal(sourceColumn int, header string) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if sourceColumn < 0 {
+		return t
+	}
+
+	for i, rt := range t.RunningTotals {
+		if rt.Source != sourceColumn {
+			continue
+		}
+		if header == "" {
+			t.RunningTotals = append(t.RunningTotals[:i:i], t.RunningTotals[i+1:]...)
+		} else {
+			t.RunningTotals[i].Header = header
+		}
+		return t
+	}
+
+	if header != "" {
+		t.RunningTotals = append(t.RunningTotals, runningTotal{Source: sourceColumn, Header: header})
+	}
+	return t
+}
+
+func (t *table) WithRowTotalColumn(header string, columns []int) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if header == "" {
+		t.RowTotal = nil
+		return t
+	}
+
+	cols := make([]int, 0, len(columns))
+	for _, c := range columns {
+		if c >= 0 {
+			cols = append(cols, c)
+		}
+	}
+	t.RowTotal = &rowTotal{Header: header, Columns: cols}
+	return t
+}
+
+func (t *table) WithRowTotalStrict(strict bool) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.RowTotalStrict = strict
+	return t
+}
+
+func (t *table) WithColumnTransform(columnIndex int, f TransformFunc) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if f == nil {
+		delete(t.Transforms, columnIndex)
+		return t
+	}
+
+	if t.Transforms == nil {
+		t.Transforms = make(map[int]TransformFunc)
+	}
+	t.Transforms[columnIndex] = f
+	return t
+}
+
+func (t *table) WithColumnAbbreviations(columnIndex int, abbr map[string]string) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(abbr) == 0 {
+		delete(t.Abbreviations, columnIndex)
+		return t
+	}
+
+	if t.Abbreviations == nil {
+		t.Abbreviations = make(map[int]map[string]string)
+	}
+	t.Abbreviations[columnIndex] = make(map[string]string, len(abbr))
+	for k, v := range abbr {
+		t.Abbreviations[columnIndex][k] = v
+	}
+	return t
+}
+
+func (t *table) WithColumnCase(columnIndex int, c CaseMode) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if c == CaseNone {
+		delete(t.Cases, columnIndex)
+		return t
+	}
+
+	if t.Cases == nil {
+		t.Cases = make(map[int]CaseMode)
+	}
+	t.Cases[columnIndex] = c
+	return t
+}
+
+func (t *table) WithBoolNormalize(columnIndex int, trueText, falseText string) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if trueText == "" && falseText == "" {
+		delete(t.BoolTexts, columnIndex)
+		return t
+	}
+
+	if t.BoolTexts == nil {
+		t.BoolTexts = make(map[int]boolTexts)
+	}
+	t.BoolTexts[columnIndex] = boolTexts{True: trueText, False: falseText}
+	return t
+}
+
+func (t *table) WithLineNumbers(enabled bool) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.LineNumbers = enabled
+	return t
+}
+
+func (t *table) WithThresholdColoring(columnIndex int, thresholds []Threshold) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.ThresholdColumn = columnIndex
+	t.Thresholds = append([]Threshold(nil), thresholds...)
+	return t
+}
+
+func (t *table) WithRowColorCycle(formatters []Formatter) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.RowColorCycle = append([]Formatter(nil), formatters...)
+	return t
+}
+
+func (t *table) WithPlainMode(plain bool) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.PlainMode = plain
+	return t
+}
+
+func (t *table) WithBoolSymbols(enabled bool) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.BoolSymbols = enabled
+	return t
+}
+
+func (t *table) WithNoTruncate(noTruncate bool) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.NoTruncate = noTruncate
+	return t
+}
+
+func (t *table) WithUniqueHeaders(unique bool) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.UniqueHeaders = unique
+	t.uniqueHeaders()
+	return t
+}
+
+func (t *table) WithVisibleWhitespace(visible bool) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.VisibleWhitespace = visible
+	return t
+}
+
+func (t *table) WithAutoLinkURLs(enabled bool) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.AutoLinkURLs = enabled
+	return t
+}
+
+func (t *table) WithWrapContinuationMarker(marker string) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.ContinuationMarker = marker
+	return t
+}
+
+func (t *table) WithPanel(title string) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.PanelTitle = title
+	return t
+}
+
+func (t *table) WithExportHeaderComment(comment string) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.ExportComment = comment
+	return t
+}
+
+func (t *table) WithLineRenderer(r LineRenderer) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.LineRenderer = r
+	return t
+}
+
+func (t *table) WithHeaderAtBottom(enabled bool) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.HeaderAtBottom = enabled
+	return t
+}
+
+func (t *table) WithFooterSeparatorRow(r rune) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.FooterSeparatorRune = r
+	return t
+}
+
+func (t *table) WithTotalWidth(width int) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.TotalWidth = width
+	return t
+}
+
+func (t *table) WithLastColumnFlushRight(flush bool) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.LastColumnFlushRight = flush
+	return t
+}
+
+// totalWidth returns the width set with WithTotalWidth, or else the width of
+// the terminal, or of the COLUMNS environment variable if that cannot be read,
+// if the Writer is a terminal. It returns zero if the width is unknown. The
+// caller must hold t.mu.
+func (t *table) totalWidth() int {
+	if t.TotalWidth > 0 {
+		return t.TotalWidth
+	}
+	fd, ok := t.writerFd()
+	if !ok || !isTerminalFd(fd) {
+		return 0
+	}
+	if width, _, err := terminalSize(fd); err == nil && width > 0 {
+		return width
+	}
+	n, err := strconv.Atoi(os.Getenv("COLUMNS"))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+func (t *table) WithOverflowFootnotes(enabled bool) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.OverflowFootnotes = enabled
+	return t
+}
+
+func (t *table) WithExactColumnWidths(exact bool) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.ExactWidths = exact
+	return t
+}
+
+func (t *table) IsTerminal() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.isTerminal()
+}
+
+// isTerminal reports whether the Writer is a terminal. The caller must hold
+// t.mu.
+func (t *table) isTerminal() bool {
+	fd, ok := t.writerFd()
+	return ok && isTerminalFd(fd)
+}
+
+// writerFd returns the file descriptor of the Writer, and whether it has one.
+// The caller must hold t.mu.
+func (t *table) writerFd() (int, bool) {
+	f, ok := t.Writer.(interface{ Fd() uintptr })
+	if !ok {
+		return 0, false
+	}
+	return int(f.Fd()), true
+}
+
+// isTerminalFd reports whether the file descriptor fd is a terminal. It is a
+// variable so that tests can stand in for a terminal.
+var isTerminalFd = term.IsTerminal
+
+// terminalSize returns the width and height of the terminal with the file
+// descriptor fd. It is a variable so that tests can stand in for a terminal.
+var terminalSize = term.GetSize
+
+func (t *table) WithValueStringer(f StringerFunc) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.ValueStringer = f
+	return t
+}
+
+// stringify converts v to the text of a cell with the ValueStringer, falling
+// back to fmt.Sprint.
+func (t *table) stringify(v interface{}) string {
+	if t.ValueStringer != nil {
+		if s, ok := t.ValueStringer(v); ok {
+			return s
+		}
+	}
+	return fmt.Sprint(v)
+}
+
+func (t *table) AddRow(vals ...interface{}) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.addRow(vals, nil)
+	return t
+}
+
+func (t *table) AddRowAligned(aligns []Alignment, vals ...interface{}) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.addRow(vals, append([]Alignment(nil), aligns...))
+	return t
+}
+
+func (t *table) AddRowf(format string, args ...interface{}) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cells := strings.Split(fmt.Sprintf(format, args...), "\t")
+	vals := make([]interface{}, len(cells))
+	for i, c := range cells {
+		vals[i] = c
+	}
+
+	t.addRow(vals, nil)
+	return t
+}
+
+func (t *table) AddSeparatorRow() Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// a nil row marks a separator, since added rows are never nil
+	t.appendRow(nil, nil)
+	return t
+}
+
+func (t *table) AddFooter(vals ...interface{}) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.growHeader(len(vals))
+
+	t.footer = make([]string, len(t.header))
+	for i, val := range vals {
+		if i >= len(t.header) {
+			break
+		}
+		t.footer[i] = t.normalize(t.stringify(val))
+	}
+	return t
+}
+
+func (t *table) PromoteFirstRowToHeader() Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.rows) == 0 {
+		return t
+	}
+
+	row := t.rows[0]
+	t.rows = t.rows[1:]
+	if t.cellAligns != nil {
+		t.cellAligns = t.cellAligns[1:]
+	}
+
+	header := make([]string, max(len(t.header), len(row)))
+	copy(header, row)
+	t.header = header
+	t.headerless = false
+	t.uniqueHeaders()
+	t.padRows()
+	return t
+}
+
+func (t *table) Normalize() Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, row := range t.rows {
+		if len(row) > len(t.header) {
+			t.rows[i] = row[:len(t.header):len(t.header)]
+		}
+	}
+	if len(t.footer) > len(t.header) {
+		t.footer = t.footer[:len(t.header):len(t.header)]
+	}
+	t.padRows()
+	return t
+}
+
+func (t *table) AddTreeRow(depth int, vals ...interface{}) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if depth > 0 && len(vals) > 0 {
+		prefix := strings.Repeat(t.TreeIndent, depth)
+		lines := strings.Split(t.stringify(vals[0]), "\n")
+		for i, line := range lines {
+			lines[i] = prefix + line
+		}
+
+		vals = append([]interface{}{strings.Join(lines, "\n")}, vals[1:]...)
+	}
+
+	t.addRow(vals, nil)
+	return t
+}
+
+func (t *table) WithUnicodeNormalization(form norm.Form) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.NormalizeUnicode = true
+	t.NormalizationForm = form
+	return t
+}
+
+func (t *table) WithTreeIndent(indent string) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.TreeIndent = indent
+	return t
+}
+
+// addRow appends vals as one or more rows, splitting multi-line values across
+// consecutive rows. Each resulting row shares the cell alignments in aligns,
+// which may be nil. The caller must hold t.mu.
+func (t *table) addRow(vals []interface{}, aligns []Alignment) {
+	t.growHeader(len(vals))
+
+	strs := make([]string, len(vals))
+	maxNumNewlines := 0
+	for i, val := range vals {
+		strs[i] = t.stringify(val)
+		maxNumNewlines = max(strings.Count(strs[i], "\n"), maxNumNewlines)
+	}
+	for i := 0; i <= maxNumNewlines; i++ {
+		row := make([]string, len(t.header))
+		for j, val := range strs {
+			if j >= len(t.header) {
+				break
+			}
+			v := strings.Split(t.normalize(val), "\n")
+			row[j] = safeOffset(v, i)
+		}
+		t.appendRow(row, aligns)
+	}
+}
+
+// growHeader appends empty headers until the table has n columns, padding the
+// existing rows to match, if truncation is disabled with WithNoTruncate. The
+// caller must hold t.mu.
+func (t *table) growHeader(n int) {
+	if !t.NoTruncate || n <= len(t.header) {
+		return
+	}
+
+	for len(t.header) < n {
+		t.header = append(t.header, "")
+	}
+	t.padRows()
+}
+
+// uniqueHeaders suffixes duplicated non-empty headers with "_2", "_3", and so
+// on, skipping any suffixed name already used by another header, if enabled
+// with WithUniqueHeaders. The caller must hold t.mu.
+func (t *table) uniqueHeaders() {
+	if !t.UniqueHeaders {
+		return
+	}
+
+	taken := make(map[string]bool, len(t.header))
+	for _, h := range t.header {
+		taken[h] = true
+	}
+
+	seen := make(map[string]bool, len(t.header))
+	for i, h := range t.header {
+		if h == "" {
+			continue
+		}
+		if !seen[h] {
+			seen[h] = true
+			continue
+		}
+
+		n := 2
+		for taken[fmt.Sprintf("%s_%d", h, n)] {
+			n++
+		}
+		name := fmt.Sprintf("%s_%d", h, n)
+		t.header[i] = name
+		taken[name] = true
+		seen[name] = true
+	}
+}
+
+// padRows pads each row, and the footer, that is shorter than the header with
+// empty cells, so that every row has a cell for each column when it is printed
+// or exported. The caller must hold t.mu.
+func (t *table) padRows() {
+	for i, row := range t.rows {
+		if row != nil && len(row) < len(t.header) {
+			padded := make([]string, len(t.header))
+			copy(padded, row)
+			t.rows[i] = padded
+		}
+	}
+
+	if t.footer != nil && len(t.footer) < len(t.header) {
+		padded := make([]string, len(t.header))
+		copy(padded, t.footer)
+		t.footer = padded
+	}
+}
+
+// normalize returns v in the Unicode normalization form set with
+// WithUnicodeNormalization, if any.
+func (t *table) normalize(v string) string {
+	if !t.NormalizeUnicode {
+		return v
+	}
+	return t.NormalizationForm.String(v)
+}
+
+func (t *table) SetRows(rows [][]string) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.rows = [][]string{}
+	t.cellAligns = nil
+	for _, row := range rows {
+		t.growHeader(len(row))
+	}
+	headerLength := len(t.header)
+
+	for _, row := range rows {
+		if row == nil {
+			row = []string{}
+		}
+		if t.NormalizeUnicode {
+			normalized := make([]string, len(row))
+			for i, v := range row {
+				normalized[i] = t.normalize(v)
+			}
+			row = normalized
+		}
+		if len(row) > headerLength {
+			t.rows = append(t.rows, row[:headerLength])
+		} else {
+			t.rows = append(t.rows, row)
+		}
+	}
+	t.padRows()
+
+	return t
+}
+
+func (t *table) SetCell(row, col int, value string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.setCell(row, col, value)
+}
+
+func (t *table) UpdateCell(row, col int, val interface{}) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.setCell(row, col, t.stringify(val))
+}
+
+// setCell replaces the value of the cell at row and col, or returns an error
+// if there is no such cell. The caller must hold t.mu.
+func (t *table) setCell(row, col int, value string) error {
+	if row < 0 || row >= len(t.rows) {
+		return fmt.Errorf("table: row %d out of range [0,%d)", row, len(t.rows))
+	}
+	if col < 0 || col >= len(t.header) {
+		return fmt.Errorf("table: column %d out of range [0,%d)", col, len(t.header))
+	}
+	if t.rows[row] == nil {
+		return fmt.Errorf("table: row %d is a separator row", row)
+	}
+
+	if len(t.rows[row]) <= col {
+		padded := make([]string, len(t.header))
+		copy(padded, t.rows[row])
+		t.rows[row] = padded
+	}
+	t.rows[row][col] = t.normalize(value)
+	return nil
+}
+
+func (t *table) RemoveRow(index int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if index < 0 || index >= len(t.rows) {
+		return fmt.Errorf("table: row %d out of range [0,%d)", index, len(t.rows))
+	}
+
+	t.rows = append(t.rows[:index:index], t.rows[index+1:]...)
+	if t.cellAligns != nil {
+		t.cellAligns = append(t.cellAligns[:index:index], t.cellAligns[index+1:]...)
+	}
+	return nil
+}
+
+func (t *table) SetColumnWidths(widths []int) Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.columnWidths = append([]int(nil), widths...)
+	return t
+}
+
+func (t *table) Print() {
+	_ = t.PrintErr()
+}
+
+func (t *table) PrintErr() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.write([]byte(t.render()))
+}
+
+func (t *table) Render() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.render()
+}
+
+func (t *table) PrintFirstColumns(n int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if n < 0 {
+		n = 0
+	}
+
+	buf := bytes.Buffer{}
+	omitted := t.columnCount() - n
+	if omitted <= 0 {
+		t.print(&buf)
+		t.write(buf.Bytes())
+		return
+	}
+
+	n = min(n, len(t.header))
+	header := append(t.header[:n:n], fmt.Sprintf("(+%d)", omitted))
+	out := t.withConfig(header)
+	out.RunningTotals = nil
+	out.RowTotal = nil
+
+	// the indicator column takes the place of column n, so none of its
+	// options may apply
+	delete(out.ColumnSeparatorRunes, n)
+	delete(out.GroupBoundaries, n)
+	delete(out.RightBorders, n)
+	delete(out.HeaderIcons, n)
+	delete(out.ZeroPad, n)
+	delete(out.DecimalPlaces, n)
+	delete(out.ThousandsSeparators, n)
+	delete(out.SmartAligns, n)
+	delete(out.Subfields, n)
+	delete(out.Transforms, n)
+	delete(out.Abbreviations, n)
+	delete(out.Cases, n)
+	delete(out.BoolTexts, n)
+	delete(out.UnitColumns, n)
+	delete(out.OutlierCaps, n)
+	delete(out.ColumnFormatters, n)
+	if len(out.columnAligns) > n {
+		out.columnAligns = out.columnAligns[:n]
+	}
+	if len(out.maxWidths) > n {
+		out.maxWidths = out.maxWidths[:n]
+	}
+
+	// row formatters, such as thresholds, still see the hidden columns
+	out.fullRows = t.rows
+	for i, row := range t.rows {
+		if row != nil {
+			row = append(row[:min(n, len(row)):min(n, len(row))], "")
+		}
+		aligns := t.rowAligns(i)
+		if len(aligns) > n {
+			aligns = aligns[:n:n]
+		}
+		out.appendRow(row, aligns)
+	}
+	if t.footer != nil {
+		out.footer = append(t.footer[:min(n, len(t.footer)):min(n, len(t.footer))], "")
+	}
+
+	out.print(&buf)
+	t.write(buf.Bytes())
+}
+
+// write writes the rendered table b to the Writer, recording the number of
+// lines it spans for ClearPrevious, and returns the error from the Writer.
+func (t *table) write(b []byte) error {
+	t.printedLines = bytes.Count(b, []byte("\n"))
+	_, err := t.Writer.Write(b)
+	return err
+}
+
+func (t *table) ClearPrevious() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.printedLines == 0 {
+		return
+	}
+
+	fmt.Fprintf(t.Writer, ansiCursorUp+"\r"+ansiClearDown, t.printedLines)
+	t.printedLines = 0
+}
+
+func (t *table) PrintPage(offset, pageSize int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	start := min(max(offset, 0), len(t.rows))
+	end := min(start+max(pageSize, 0), len(t.rows))
+
+	buf := bytes.Buffer{}
+	t.printRange(&buf, start, end)
+	t.write(buf.Bytes())
+}
+
+func (t *table) PageCount(pageSize int) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if pageSize <= 0 {
+		return 0
+	}
+	return (len(t.rows) + pageSize - 1) / pageSize
+}
+
+func (t *table) Lines() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return strings.Split(strings.TrimSuffix(t.render(), "\n"), "\n")
+}
+
+// render returns the table as Print writes it. The caller must hold t.mu.
+func (t *table) render() string {
+	buf := bytes.Buffer{}
+	t.print(&buf)
+	return buf.String()
+}
+
+// print writes the table to w. The caller must hold t.mu.
+func (t *table) print(w io.Writer) {
+	t.printRange(w, 0, len(t.rows))
+}
+
+// printRange writes the table to w with only the rows from start up to end.
+// The caller must hold t.mu.
+func (t *table) printRange(w io.Writer, start, end int) {
+	if t.PanelTitle == "" || t.PlainMode {
+		t.printTable(w, start, end)
+		return
+	}
+
+	buf := bytes.Buffer{}
+	t.printTable(&buf, start, end)
+	t.printPanel(w, strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n"))
+}
+
+// printPanel writes lines to w inside the box drawn by WithPanel.
+func (t *table) printPanel(w io.Writer, lines []string) {
+	inner := 0
+	if t.LineRenderer != nil {
+		// a renderer may change the width of the lines in any way
+		for _, line := range lines {
+			inner = max(inner, t.Width(line))
+		}
+	} else {
+		inner = t.lineWidth()
+	}
+
+	// the content is preceded by a space, and followed by one if it does not
+	// end with padding. The title is surrounded by at least "─ " and " ─" in
+	// the top border.
+	width := max(inner+1+t.panelGap(), t.Width(t.PanelTitle)+4)
+	fill := strings.Repeat(" ", width-inner-1)
+
+	var sb strings.Builder
+	sb.WriteString("┌─ " + t.PanelTitle + " " + strings.Repeat("─", width-t.Width(t.PanelTitle)-3) + "┐\n")
+	for _, line := range lines {
+		if t.LineRenderer != nil {
+			fill = t.lenOffset(line, inner) + strings.Repeat(" ", width-inner-1)
+		}
+		sb.WriteString("│ " + line + fill + "│\n")
+	}
+	sb.WriteString("└" + strings.Repeat("─", width) + "┘\n")
+	io.WriteString(w, sb.String())
+}
+
+// lineWidth returns the width of each line of the table, as laid out by the
+// last call to calculateWidths, assuming formatters do not change it.
+func (t *table) lineWidth() int {
+	width := t.Width(t.linePrefix())
+	for i, w := range t.widths {
+		width += w + t.Width(t.columnSuffix(i))
+	}
+	if t.LineNumbers {
+		width += t.numberWidth + t.Padding
+	}
+	return width
+}
+
+// flushLastColumn widens the second to last column so that the last one ends
+// at the table's total width, if enabled with WithLastColumnFlushRight.
+func (t *table) flushLastColumn() {
+	if !t.LastColumnFlushRight || len(t.widths) < 2 {
+		return
+	}
+
+	total := t.totalWidth()
+	if t.PanelTitle != "" {
+		// the panel's borders take up "│ " and "│"
+		total -= 3 + t.panelGap()
+	}
+	if extra := total - t.lineWidth(); total > 0 && extra > 0 {
+		t.widths[len(t.widths)-2] += extra
+	}
+}
+
+// panelGap returns the width of the space added between the lines of the
+// table and the right border of the panel, for lines that do not end with
+// padding.
+func (t *table) panelGap() int {
+	if t.Style != nil && t.Style.SideBorders {
+		return 1
+	}
+	return 0
+}
+
+// printTable writes the table to w with only the rows from start up to end,
+// without the panel. Columns are sized to fit all of the rows.
+func (t *table) printTable(w io.Writer, start, end int) {
+	rows := t.displayRows()
+
+	// the footer is only printed with the last row, and is measured and
+	// transformed as the last of rows until the widths are calculated.
+	var footer []string
+	if t.footer != nil && end == len(t.rows) {
+		footer = t.displayFooter()
+	}
+
+	if t.PlainMode {
+		if footer != nil {
+			rows = append(rows, footer)
+			end++
+		}
+		t.printPlain(w, rows[start:end])
+		return
+	}
+
+	if footer != nil {
+		rows = append(rows, footer)
+	}
+
+	if t.VisibleWhitespace {
+		for _, row := range rows {
+			for i, v := range row {
+				row[i] = visibleWhitespace(v)
+			}
+		}
+	}
+
+	for col := range t.UnitColumns {
+		t.alignUnits(rows, col)
+	}
+
+	for col, percentile := range t.OutlierCaps {
+		t.capOutliers(rows, col, percentile)
+	}
+
+	for col := range t.maxWidths {
+		if limit := t.maxWidth(col); limit > 0 {
+			for _, row := range rows {
+				if col < len(row) {
+					row[col] = t.hardWrap(row[col], limit)
+				}
+			}
+		}
+	}
+
+	// the marker is added once cells are wrapped, so that it also continues
+	// the lines they were wrapped onto.
+	if t.ContinuationMarker != "" {
+		for _, row := range rows {
+			for i, v := range row {
+				row[i] = strings.ReplaceAll(v, "\n", "\n"+t.ContinuationMarker)
+			}
+		}
+	}
+
+	t.symbolCols = nil
+	if t.BoolSymbols && t.isTerminal() {
+		t.useBoolSymbols(rows)
+	}
+
+	format := t.lineFormat()
+	t.calculateWidths(rows)
+	if footer != nil {
+		rows = rows[:len(rows)-1]
+	}
+
+	if t.LineNumbers {
+		format = "%s" + format
+		t.numberWidth = max(t.Width(lineNumberHeader), len(strconv.Itoa(len(rows))))
+	}
+	t.flushLastColumn()
+
+	t.footnotes = nil
+	hasHeader := !t.headerless
+	hasSeparator := hasHeader && (t.HeaderSeparatorRune != 0 || len(t.ColumnSeparatorRunes) > 0)
+	if t.Style != nil {
+		hasSeparator = hasHeader && t.Style.HeaderSeparator
+	}
+	hasBorder := t.Style != nil && t.Style.OuterBorder
+
+	if hasBorder {
+		t.printStyleRule(w, t.Style.TopLeft, t.Style.TopJunction, t.Style.TopRight)
+	}
+	if hasHeader {
+		t.printHeader(w, format)
+	}
+	if hasSeparator {
+		t.printHeaderSeparator(w, format)
+	}
+	number, afterRow := 0, false
+	for i, row := range rows[:end] {
+		switch {
+		case row == nil:
+			if i >= start {
+				t.printRule(w, format)
+				afterRow = false
+			}
+		case i >= start:
+			if afterRow && t.Style != nil && t.Style.RowSeparators {
+				t.printRule(w, format)
+			}
+			number++
+			t.printRow(w, format, i, number, row)
+			afterRow = true
+		default:
+			number++
+		}
+	}
+
+	if footer != nil {
+		if t.FooterSeparatorRune != 0 {
+			t.printRuleRune(w, format, t.FooterSeparatorRune)
+		}
+		t.printFooter(w, format, footer)
+	}
+
+	if hasHeader && t.HeaderAtBottom {
+		if hasSeparator {
+			t.printHeaderSeparator(w, format)
+		}
+		t.printHeader(w, format)
+	}
+	if hasBorder {
+		t.printStyleRule(w, t.Style.BottomLeft, t.Style.BottomJunction, t.Style.BottomRight)
+	}
+
+	for i, note := range t.footnotes {
+		fmt.Fprintf(w, "[%d] %s\n", i+1, note)
+	}
+}
+
+// lineFormat returns the format string used to print each line of the table,
+// with a verb for each column followed by its columnSuffix.
+func (t *table) lineFormat() string {
+	var sb strings.Builder
+	sb.WriteString(strings.ReplaceAll(t.linePrefix(), "%", "%%"))
+	for i := 0; i < t.columnCount(); i++ {
+		sb.WriteString("%s")
+		sb.WriteString(strings.ReplaceAll(t.columnSuffix(i), "%", "%%"))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// columnSuffix returns the text printed after the column at col on every line:
+// its right border, if any, followed by the column group separator, if the
+// column is a group boundary. With a Style, it is the line between columns,
+// or the right border after the last column.
+func (t *table) columnSuffix(col int) string {
+	if t.Style != nil {
+		switch {
+		case col < t.columnCount()-1:
+			return string(t.Style.Vertical) + " "
+		case t.Style.SideBorders:
+			return string(t.Style.Vertical)
+		default:
+			return ""
+		}
+	}
+
+	suffix := ""
+	if r, ok := t.RightBorders[col]; ok {
+		suffix += string(r) + " "
+	}
+	if t.GroupBoundaries[col] {
+		suffix += t.GroupSeparator
+	}
+	return suffix
+}
+
+// printPlain writes the header and rows as tab-separated lines, without any
+// padding, formatting or header icons.
+func (t *table) printPlain(w io.Writer, rows [][]string) {
+	if !t.headerless {
+		fmt.Fprintln(w, strings.Join(t.columnHeaders(), "\t"))
+	}
+	for _, row := range rows {
+		if row == nil {
+			continue
+		}
+		for _, line := range rowLines(row) {
+			fmt.Fprintln(w, strings.Join(line, "\t"))
+		}
+	}
+}
+
+// columnCount returns the number of columns printed, including any computed
+// columns appended after the header's.
+func (t *table) columnCount() int {
+	n := len(t.header) + len(t.RunningTotals)
+	if t.RowTotal != nil {
+		n++
+	}
+	return n
+}
+
+// columnHeaders returns a copy of the header, followed by the headers of any
+// computed columns.
+func (t *table) columnHeaders() []string {
+	out := make([]string, 0, t.columnCount())
+	out = append(out, t.header...)
+	for _, rt := range t.RunningTotals {
+		out = append(out, rt.Header)
+	}
+	if t.RowTotal != nil {
+		out = append(out, t.RowTotal.Header)
+	}
+	return out
+}
+
+// displayHeader returns the headers of columnHeaders with any column header
+// icons applied, as they should be measured and printed.
+func (t *table) displayHeader() []string {
+	out := t.columnHeaders()
+	for i, h := range out {
+		if icon, ok := t.HeaderIcons[i]; ok {
+			out[i] = icon + " " + h
+		}
+	}
+	return out
+}
+
+// displayRows returns a copy of the rows, with the cells of any computed
+// columns appended and all column options applied, as they should be measured
+// and printed. Every returned row has a cell for each printed column, except
+// for rows added by AddSeparatorRow, which remain nil.
+func (t *table) displayRows() [][]string {
+	out := make([][]string, len(t.rows))
+	for i, row := range t.rows {
+		if row == nil {
+			continue
+		}
+		out[i] = make([]string, t.columnCount())
+		copy(out[i], row)
+	}
+
+	for k, rt := range t.RunningTotals {
+		col := len(t.header) + k
+		sum, places := 0.0, 0
+		for i, row := range t.rows {
+			if row == nil {
+				continue
+			}
+			v := safeOffset(row, rt.Source)
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				sum += f
+				places = max(places, decimalPlaces(v))
+			}
+			out[i][col] = strconv.FormatFloat(sum, 'f', places, 64)
+		}
+	}
+
+	if t.RowTotal != nil {
+		col := t.columnCount() - 1
+		for i, row := range t.rows {
+			if row != nil {
+				out[i][col] = t.rowTotal(row)
+			}
+		}
+	}
+
+	for _, row := range out {
+		for j, v := range row {
+			row[j] = t.displayCell(j, v)
+		}
+	}
+	return out
+}
+
+// displayFooter returns a copy of the footer with a cell for each printed
+// column and all column options applied, as it should be measured and printed.
+// The cells of computed columns are empty.
+func (t *table) displayFooter() []string {
+	out := make([]string, t.columnCount())
+	copy(out, t.footer)
+	for i, v := range out {
+		out[i] = t.displayCell(i, v)
+	}
+	return out
+}
+
+// unitPattern splits a cell laid out by WithUnitColumn into its number and
+// unit.
+var unitPattern = regexp.MustCompile(`^([-+]?[0-9]+(?:\.[0-9]+)?)\s*(\S.*)?$`)
+
+// capOutliers wraps the cells of rows in the column at col that are wider than
+// the given percentile of the column's cell widths, or its header if wider.
+func (t *table) capOutliers(rows [][]string, col int, percentile float64) {
+	var widths []int
+	for _, row := range rows {
+		if col < len(row) {
+			widths = append(widths, t.cellWidth(row[col]))
+		}
+	}
+	if len(widths) == 0 {
+		return
+	}
+
+	sort.Ints(widths)
+	rank := int(math.Ceil(percentile/100*float64(len(widths)))) - 1
+	limit := widths[min(max(rank, 0), len(widths)-1)]
+	if col < len(t.header) {
+		limit = max(limit, t.Width(t.displayHeader()[col]))
+	}
+
+	for _, row := range rows {
+		if col < len(row) {
+			row[col] = t.hardWrap(row[col], limit)
+		}
+	}
+}
+
+// hardWrap breaks each line of s into lines no wider than w, wherever the
+// width runs out, without splitting grapheme clusters. Every line after the
+// first leaves room for the continuation marker, if any. Every line holds at
+// least one cluster, even if it is wider.
+func (t *table) hardWrap(s string, w int) string {
+	marker := t.Width(t.ContinuationMarker)
+	if w <= 0 || (marker == 0 && t.cellWidth(s) <= w) {
+		return s
+	}
+
+	var out []string
+	for _, line := range strings.Split(s, "\n") {
+		clusters := graphemes(line)
+		for {
+			limit := w
+			if len(out) > 0 {
+				limit -= marker
+			}
+			if len(clusters) <= 1 || t.Width(strings.Join(clusters, "")) <= limit {
+				break
+			}
+
+			n := 1
+			for n < len(clusters) && t.Width(strings.Join(clusters[:n+1], "")) <= limit {
+				n++
+			}
+			out = append(out, strings.Join(clusters[:n], ""))
+			clusters = clusters[n:]
+		}
+		out = append(out, strings.Join(clusters, ""))
+	}
+	return strings.Join(out, "\n")
 }
 
-func (t *table) WithPadding(p int) Table {
-	if p < 0 {
-		p = 0
+// alignUnits rewrites the cells of rows in the column at col so that their
+// numbers are right-aligned and their units left-aligned after them.
+func (t *table) alignUnits(rows [][]string, col int) {
+	var (
+		matches             = make([][]string, len(rows))
+		numWidth, unitWidth int
+	)
+	for i, row := range rows {
+		if col >= len(row) {
+			continue
+		}
+		if m := unitPattern.FindStringSubmatch(row[col]); m != nil {
+			matches[i] = m
+			numWidth = max(numWidth, t.Width(m[1]))
+			unitWidth = max(unitWidth, t.Width(m[2]))
+		}
 	}
 
-	t.Padding = p
-	return t
+	for i, m := range matches {
+		if m == nil {
+			continue
+		}
+		v := t.lenOffset(m[1], numWidth) + m[1]
+		if unitWidth > 0 {
+			v += " " + m[2] + t.lenOffset(m[2], unitWidth)
+		}
+		rows[i][col] = v
+	}
 }
 
-func (t *table) WithWriter(w io.Writer) Table {
-	if w == nil {
-		w = os.Stdout
+// The symbols and ANSI colors used by WithBoolSymbols.
+const (
+	trueSymbol  = "✓"
+	falseSymbol = "✗"
+	ansiGreen   = "\x1b[32m"
+	ansiRed     = "\x1b[31m"
+	ansiReset   = "\x1b[0m"
+)
+
+// useBoolSymbols replaces the cells of rows in columns of type TypeBool with
+// trueSymbol or falseSymbol, recording the columns in t.symbolCols so that
+// printRow can color them once they are padded.
+func (t *table) useBoolSymbols(rows [][]string) {
+	for col := range t.header {
+		if t.columnType(col) != TypeBool {
+			continue
+		}
+
+		if t.symbolCols == nil {
+			t.symbolCols = make(map[int]bool)
+		}
+		t.symbolCols[col] = true
+
+		for i, row := range rows {
+			if row == nil {
+				continue
+			}
+			// rows may end with the footer, after the last of t.rows
+			raw := t.footer
+			if i < len(t.rows) {
+				raw = t.rows[i]
+			}
+			if b, err := strconv.ParseBool(safeOffset(raw, col)); err == nil {
+				row[col] = falseSymbol
+				if b {
+					row[col] = trueSymbol
+				}
+			}
+		}
 	}
+}
 
-	t.Writer = w
-	return t
+// colorBoolSymbol colors the boolean symbol in the padded cell s.
+func colorBoolSymbol(s string) string {
+	s = strings.Replace(s, trueSymbol, ansiGreen+trueSymbol+ansiReset, 1)
+	return strings.Replace(s, falseSymbol, ansiRed+falseSymbol+ansiReset, 1)
 }
 
-func (t *table) WithWidthFunc(f WidthFunc) Table {
-	t.Width = f
-	return t
+// rowTotal describes the column added by WithRowTotalColumn.
+type rowTotal struct {
+	Header  string
+	Columns []int
 }
 
-func (t *table) AddRow(vals ...interface{}) Table {
-	maxNumNewlines := 0
-	for _, val := range vals {
-		maxNumNewlines = max(strings.Count(fmt.Sprint(val), "\n"), maxNumNewlines)
-	}
-	for i := 0; i <= maxNumNewlines; i++ {
-		row := make([]string, len(t.header))
-		for j, val := range vals {
-			if j >= len(t.header) {
-				break
+// rowTotal returns the cell of the WithRowTotalColumn column for row.
+func (t *table) rowTotal(row []string) string {
+	sum, places := 0.0, 0
+	for _, col := range t.RowTotal.Columns {
+		v := safeOffset(row, col)
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			if t.RowTotalStrict {
+				return ""
 			}
-			v := strings.Split(fmt.Sprint(val), "\n")
-			row[j] = safeOffset(v, i)
+			continue
 		}
-		t.rows = append(t.rows, row)
+		sum += f
+		places = max(places, decimalPlaces(v))
 	}
+	return strconv.FormatFloat(sum, 'f', places, 64)
+}
 
-	return t
+// runningTotal describes a column added by WithRunningTotal.
+type runningTotal struct {
+	Source int
+	Header string
 }
 
-func (t *table) SetRows(rows [][]string) Table {
-	t.rows = [][]string{}
-	headerLength := len(t.header)
+// decimalPlaces returns the number of digits after the decimal point in the
+// number s.
+func decimalPlaces(s string) int {
+	i := strings.IndexByte(s, '.')
+	if i < 0 {
+		return 0
+	}
 
-	for _, row := range rows {
-		if len(row) > headerLength {
-			t.rows = append(t.rows, row[:headerLength])
-		} else {
-			t.rows = append(t.rows, row)
+	n := 0
+	for _, r := range s[i+1:] {
+		if r < '0' || r > '9' {
+			break
 		}
+		n++
 	}
+	return n
+}
 
-	return t
+// displayCell applies the options configured for the column at col to v.
+func (t *table) displayCell(col int, v string) string {
+	if f, ok := t.Transforms[col]; ok {
+		v = f(v)
+	}
+	if a, ok := t.Abbreviations[col][v]; ok {
+		v = a
+	}
+	if c, ok := t.Cases[col]; ok {
+		v = c.apply(v)
+	}
+	if texts, ok := t.BoolTexts[col]; ok {
+		if b, ok := parseBoolish(v); ok {
+			v = texts.text(b)
+		}
+	}
+	if sep, ok := t.Subfields[col]; ok {
+		fields := strings.Split(v, sep)
+		for i, f := range fields {
+			fields[i] = strings.TrimSpace(f)
+		}
+		v = strings.Join(fields, "\n")
+	}
+	if places, ok := t.DecimalPlaces[col]; ok {
+		v = roundDecimal(v, places)
+	}
+	if n, ok := t.ZeroPad[col]; ok {
+		v = zeroPad(v, n)
+	}
+	if sep, ok := t.ThousandsSeparators[col]; ok {
+		v = groupThousands(v, sep)
+	}
+	return v
 }
 
-func (t *table) Print() {
-	format := strings.Repeat("%s", len(t.header)) + "\n"
-	t.calculateWidths()
+func (t *table) printHeaderSeparator(w io.Writer, format string) {
+	if t.Style != nil {
+		t.printStyleRule(w, t.Style.LeftJunction, t.Style.Cross, t.Style.RightJunction)
+		return
+	}
+
+	header := t.displayHeader()
+	separators := make([]string, len(header))
+	for index, headerName := range header {
+		r, ok := t.ColumnSeparatorRunes[index]
+		if !ok {
+			r = t.HeaderSeparatorRune
+		}
+		separators[index] = t.separator(headerName, r)
+	}
+
+	if t.SeparatorJunction != 0 {
+		vals := make([]interface{}, len(header))
+		for i := range header {
+			r, ok := t.ColumnSeparatorRunes[i]
+			if !ok {
+				r = t.HeaderSeparatorRune
+			}
+			vals[i] = t.fill(r, t.widths[i])
+		}
+		if t.LineNumbers {
+			vals = append([]interface{}{t.fill(t.HeaderSeparatorRune, t.numberWidth+t.Padding)}, vals...)
+		}
+		t.writeLine(w, t.junctionFormat(t.HeaderSeparatorRune), vals, t.HeaderFormatter)
+		return
+	}
+
+	vals := t.applyWidths(separators, t.widths, nil)
+	vals = t.withLineNumber(t.separator(lineNumberHeader, t.HeaderSeparatorRune), vals)
+	t.writeLine(w, format, vals, t.HeaderFormatter)
+}
+
+// fill returns a run of r spanning width, or spaces if r is zero.
+func (t *table) fill(r rune, width int) string {
+	if r == 0 {
+		return strings.Repeat(" ", width)
+	}
+	return strings.Repeat(string(r), width/max(t.Width(string(r)), 1))
+}
 
-	t.printHeader(format)
-	if t.HeaderSeparatorRune != 0 {
-		t.printHeaderSeparator(format)
+// junctionFormat returns a line format like lineFormat, for a horizontal line
+// of r crossing the column borders and group separators. The characters of
+// each are replaced by the SeparatorJunction rune, and their spaces by r.
+func (t *table) junctionFormat(r rune) string {
+	var sb strings.Builder
+	if t.LineNumbers {
+		sb.WriteString("%s")
 	}
-	for _, row := range t.rows {
-		t.printRow(format, row)
+	for i := 0; i < t.columnCount(); i++ {
+		sb.WriteString("%s")
+		for _, c := range t.columnSuffix(i) {
+			switch {
+			case c != ' ':
+				c = t.SeparatorJunction
+			case r != 0 && t.Width(string(r)) == 1:
+				c = r
+			}
+			if c == '%' {
+				sb.WriteByte('%')
+			}
+			sb.WriteRune(c)
+		}
 	}
+	sb.WriteString("\n")
+	return sb.String()
 }
 
-func (t *table) printHeaderSeparator(format string) {
-	separators := make([]string, len(t.header))
+// separator returns a run of r spanning the width of text. A zero r results in
+// an empty separator.
+func (t *table) separator(text string, r rune) string {
+	if r == 0 {
+		return ""
+	}
 
 	// The separator could be any unicode char. Since some chars take up more
 	// than one cell in a monospace context, we can get a number higher than 1
 	// here. Am example would be this emoji 🤣.
-	separatorCellWidth := t.Width(string([]rune{t.HeaderSeparatorRune}))
-	for index, headerName := range t.header {
-		headerCellWidth := t.Width(headerName)
-		// Note that this might not be evenly divisble. In this case we'll get a
-		// separator that is at least 1 cell shorter than the header. This was
-		// an intentional design decision in order to prevent widening the cell
-		// or overstepping the column bounds.
-		repeatCharTimes := headerCellWidth / separatorCellWidth
-		separator := make([]rune, repeatCharTimes)
-		for i := 0; i < repeatCharTimes; i++ {
-			separator[i] = t.HeaderSeparatorRune
-		}
-		separators[index] = string(separator)
-	}
-
-	vals := t.applyWidths(separators, t.widths)
-	if t.HeaderFormatter != nil {
-		txt := t.HeaderFormatter(format, vals...)
-		fmt.Fprint(t.Writer, txt)
-	} else {
-		fmt.Fprintf(t.Writer, format, vals...)
+	separatorCellWidth := t.Width(string([]rune{r}))
+	headerCellWidth := t.Width(text)
+	// Note that this might not be evenly divisble. In this case we'll get a
+	// separator that is at least 1 cell shorter than the header. This was
+	// an intentional design decision in order to prevent widening the cell
+	// or overstepping the column bounds.
+	repeatCharTimes := headerCellWidth / separatorCellWidth
+	separator := make([]rune, repeatCharTimes)
+	for i := 0; i < repeatCharTimes; i++ {
+		separator[i] = r
 	}
+	return string(separator)
+}
+
+func (t *table) printHeader(w io.Writer, format string) {
+	vals := t.applyWidths(t.displayHeader(), t.widths, nil)
+	vals = t.withLineNumber(lineNumberHeader, vals)
+	t.writeLine(w, format, vals, t.HeaderFormatter)
 }
 
-func (t *table) printHeader(format string) {
-	vals := t.applyWidths(t.header, t.widths)
-	if t.HeaderFormatter != nil {
-		txt := t.HeaderFormatter(format, vals...)
-		fmt.Fprint(t.Writer, txt)
+// writeLine writes vals to w using format, or the LineRenderer if one is set.
+// The Formatter f, which may be nil, is applied to the whole line.
+func (t *table) writeLine(w io.Writer, format string, vals []interface{}, f Formatter) {
+	if t.LineRenderer != nil {
+		cells := make([]string, len(vals))
+		for i, v := range vals {
+			cells[i] = fmt.Sprint(v)
+		}
+		format, vals = "%s\n", []interface{}{t.LineRenderer(cells)}
+	}
+
+	if f != nil {
+		fmt.Fprint(w, f(format, vals...))
 	} else {
-		fmt.Fprintf(t.Writer, format, vals...)
+		fmt.Fprintf(w, format, vals...)
+	}
+}
+
+// printRule prints a line of the header separator rune, or '-' if there is
+// none, spanning every column.
+func (t *table) printRule(w io.Writer, format string) {
+	r := t.HeaderSeparatorRune
+	if r == 0 {
+		r = '-'
+	}
+	t.printRuleRune(w, format, r)
+}
+
+// printRuleRune prints a line of r spanning every column, or a line of the
+// Style if there is one.
+func (t *table) printRuleRune(w io.Writer, format string, r rune) {
+	if t.Style != nil {
+		t.printStyleRule(w, t.Style.LeftJunction, t.Style.Cross, t.Style.RightJunction)
+		return
+	}
+
+	vals := make([]interface{}, len(t.widths))
+	for i, width := range t.widths {
+		vals[i] = t.fill(r, width)
+	}
+	if t.LineNumbers {
+		vals = append([]interface{}{t.fill(r, t.numberWidth+t.Padding)}, vals...)
+	}
+	if t.SeparatorJunction != 0 {
+		format = t.junctionFormat(r)
+	}
+	t.writeLine(w, format, vals, nil)
+}
+
+// printRow prints the row at index, spreading any multi-line cells across as
+// many lines as needed. The line number, if enabled, is number.
+func (t *table) printRow(w io.Writer, format string, index, number int, row []string) {
+	for l, line := range rowLines(row) {
+		if t.OverflowFootnotes {
+			line = t.addFootnotes(line)
+		}
+		vals := t.applyWidths(line, t.widths, t.smartAligns(line, t.rowAligns(index)))
+
+		if t.AutoLinkURLs {
+			for i, v := range vals {
+				vals[i] = linkURLs(v.(string))
+			}
+		}
+
+		for i := range t.symbolCols {
+			if i < len(vals) {
+				vals[i] = colorBoolSymbol(vals[i].(string))
+			}
+		}
+
+		if t.FirstColumnFormatter != nil {
+			vals[0] = t.FirstColumnFormatter("%s", vals[0])
+		}
+
+		for i, f := range t.ColumnFormatters {
+			if i < len(vals) {
+				vals[i] = f("%s", vals[i])
+			}
+		}
+
+		if f := t.rowFormatter(index); f != nil {
+			for i, v := range vals {
+				vals[i] = f("%s", v)
+			}
+		}
+
+		label := ""
+		if l == 0 {
+			label = strconv.Itoa(number)
+		}
+
+		vals = t.withLineNumber(label, vals)
+		t.writeLine(w, format, vals, nil)
+	}
+}
+
+// printFooter prints the footer, spreading any multi-line cells across as many
+// lines as needed. It is aligned like the rows, without their formatters.
+func (t *table) printFooter(w io.Writer, format string, footer []string) {
+	for _, line := range rowLines(footer) {
+		vals := t.applyWidths(line, t.widths, nil)
+		for i := range t.symbolCols {
+			if i < len(vals) {
+				vals[i] = colorBoolSymbol(vals[i].(string))
+			}
+		}
+		vals = t.withLineNumber("", vals)
+		t.writeLine(w, format, vals, nil)
+	}
+}
+
+// rowLines splits the cells of row on newlines, returning a row for each line.
+// Cells with fewer lines than others in the row are empty on the extra lines.
+func rowLines(row []string) [][]string {
+	n := 1
+	split := make([][]string, len(row))
+	for i, v := range row {
+		split[i] = strings.Split(v, "\n")
+		n = max(n, len(split[i]))
+	}
+
+	if n == 1 {
+		return [][]string{row}
+	}
+
+	lines := make([][]string, n)
+	for l := range lines {
+		lines[l] = make([]string, len(row))
+		for i := range row {
+			lines[l][i] = safeOffset(split[i], l)
+		}
+	}
+	return lines
+}
+
+// rowFormatter returns the Formatter applied to every cell of the row at index,
+// or nil if there is none. A threshold set with WithThresholdColoring takes
+// precedence over the row color cycle. Thresholds are read from fullRows
+// instead of rows when it is set.
+func (t *table) rowFormatter(index int) Formatter {
+	row := t.rows[index]
+	if t.fullRows != nil {
+		row = t.fullRows[index]
+	}
+	if f := t.thresholdFormatter(row); f != nil {
+		return f
+	}
+	if len(t.RowColorCycle) > 0 {
+		return t.RowColorCycle[index%len(t.RowColorCycle)]
+	}
+	return nil
+}
+
+// thresholdFormatter returns the Formatter of the highest threshold met by the
+// value of row in the threshold column, or nil if none applies.
+func (t *table) thresholdFormatter(row []string) Formatter {
+	if len(t.Thresholds) == 0 {
+		return nil
+	}
+
+	v, err := strconv.ParseFloat(safeOffset(row, t.ThresholdColumn), 64)
+	if err != nil {
+		return nil
+	}
+
+	var (
+		f     Formatter
+		found bool
+		best  float64
+	)
+	for _, th := range t.Thresholds {
+		if v >= th.Value && (!found || th.Value >= best) {
+			f, found, best = th.Formatter, true, th.Value
+		}
 	}
+	return f
 }
 
-func (t *table) printRow(format string, row []string) {
-	vals := t.applyWidths(row, t.widths)
+// lineNumberHeader is the header of the column added by WithLineNumbers.
+const lineNumberHeader = "#"
 
-	if t.FirstColumnFormatter != nil {
-		vals[0] = t.FirstColumnFormatter("%s", vals[0])
+// withLineNumber prepends label, right-aligned in the line number column, to
+// vals if line numbers are enabled.
+func (t *table) withLineNumber(label string, vals []interface{}) []interface{} {
+	if !t.LineNumbers {
+		return vals
 	}
 
-	fmt.Fprintf(t.Writer, format, vals...)
+	cell := t.lenOffset(label, t.numberWidth) + label + strings.Repeat(" ", t.Padding)
+	return append([]interface{}{cell}, vals...)
 }
 
-func (t *table) calculateWidths() {
-	t.widths = make([]int, len(t.header))
-	for _, row := range t.rows {
+// measureWidths calculates the column widths like calculateWidths, also
+// fitting the footer, if any, as it is printed after rows.
+func (t *table) measureWidths(rows [][]string) {
+	if t.footer != nil {
+		rows = append(rows[:len(rows):len(rows)], t.displayFooter())
+	}
+	t.calculateWidths(rows)
+}
+
+func (t *table) calculateWidths(rows [][]string) {
+	t.widths = make([]int, t.columnCount())
+	for _, row := range rows {
 		for i, v := range row {
-			if w := t.Width(v) + t.Padding; w > t.widths[i] {
+			if w := t.cellWidth(v) + t.Padding; w > t.widths[i] {
 				t.widths[i] = w
 			}
 		}
 	}
 
-	for i, v := range t.header {
+	for i, v := range t.displayHeader() {
 		if w := t.Width(v) + t.Padding; w > t.widths[i] {
 			t.widths[i] = w
 		}
 	}
+
+	for i, w := range t.columnWidths {
+		if i >= len(t.widths) || w <= 0 {
+			continue
+		}
+		if w += t.Padding; t.ExactWidths || w > t.widths[i] {
+			t.widths[i] = w
+		}
+	}
+
+	for i := range t.widths {
+		if limit := t.maxWidth(i); limit > 0 {
+			t.widths[i] = min(t.widths[i], limit+t.Padding)
+		}
+	}
 }
 
-func (t *table) applyWidths(row []string, widths []int) []interface{} {
+// maxWidth returns the width set for the column at col by WithMaxColumnWidth,
+// widened to fit its header, or 0 if the column has no cap.
+func (t *table) maxWidth(col int) int {
+	if col >= len(t.maxWidths) || t.maxWidths[col] <= 0 {
+		return 0
+	}
+
+	limit := t.maxWidths[col]
+	if h := t.displayHeader(); col < len(h) {
+		limit = max(limit, t.Width(h[col]))
+	}
+	return limit
+}
+
+// fitWidth cuts s short to the width set for the column at col by
+// SetColumnWidths, if the table uses exact widths.
+func (t *table) fitWidth(col int, s string) string {
+	if !t.ExactWidths || col >= len(t.columnWidths) || t.columnWidths[col] <= 0 {
+		return s
+	}
+
+	return t.cut(s, t.columnWidths[col])
+}
+
+// cut returns s without as many trailing grapheme clusters as needed to fit
+// within w.
+func (t *table) cut(s string, w int) string {
+	if t.Width(s) <= w {
+		return s
+	}
+
+	clusters := graphemes(s)
+	for len(clusters) > 0 && t.Width(strings.Join(clusters, "")) > w {
+		clusters = clusters[:len(clusters)-1]
+	}
+	return strings.Join(clusters, "")
+}
+
+// graphemes splits s into its grapheme clusters, such as a letter with its
+// combining accents or an emoji with its modifiers, so that they are never
+// split apart when s is cut short or wrapped.
+func graphemes(s string) []string {
+	var clusters []string
+	g := uniseg.NewGraphemes(s)
+	for g.Next() {
+		clusters = append(clusters, g.Str())
+	}
+	return clusters
+}
+
+// addFootnotes returns a copy of line in which each cell too wide for its
+// exact width is cut short to end with a footnote marker, recording the full
+// value as a footnote to print below the table.
+func (t *table) addFootnotes(line []string) []string {
+	out := append([]string(nil), line...)
+	for i, v := range out {
+		if !t.ExactWidths || i >= len(t.columnWidths) || t.columnWidths[i] <= 0 {
+			continue
+		}
+		w := t.columnWidths[i]
+		if t.Width(v) <= w {
+			continue
+		}
+
+		t.footnotes = append(t.footnotes, v)
+		marker := fmt.Sprintf("[%d]", len(t.footnotes))
+		out[i] = t.cut(v, max(w-t.Width(marker), 0)) + marker
+	}
+	return out
+}
+
+// cellWidth returns the width of the widest line of s.
+func (t *table) cellWidth(s string) int {
+	if !strings.Contains(s, "\n") {
+		return t.Width(s)
+	}
+
+	width := 0
+	for _, line := range strings.Split(s, "\n") {
+		width = max(width, t.Width(line))
+	}
+	return width
+}
+
+func (t *table) applyWidths(row []string, widths []int, aligns []Alignment) []interface{} {
 	out := make([]interface{}, len(row))
 	for i, s := range row {
-		out[i] = s + t.lenOffset(s, widths[i])
+		a := t.columnAlign(i)
+		switch {
+		case t.LastColumnFlushRight && i > 0 && i == len(widths)-1:
+			a = AlignRight
+		case i < len(aligns):
+			a = aligns[i]
+		}
+		out[i] = t.align(t.fitWidth(i, s), widths[i], a)
 	}
 	return out
 }
@@ -329,6 +3503,13 @@ func (t *table) lenOffset(s string, w int) string {
 	return strings.Repeat(" ", l)
 }
 
+func min(i1, i2 int) int {
+	if i1 < i2 {
+		return i1
+	}
+	return i2
+}
+
 func max(i1, i2 int) int {
 	if i1 > i2 {
 		return i1
@@ -336,8 +3517,181 @@ func max(i1, i2 int) int {
 	return i2
 }
 
+// zeroPad left-pads the integer part of the decimal number s with zeros to n
+// digits, keeping any sign in front. Values that are not plain decimal numbers
+// are returned unchanged.
+func zeroPad(s string, n int) string {
+	if _, err := strconv.ParseFloat(s, 64); err != nil {
+		return s
+	}
+
+	sign, digits := "", s
+	if digits[0] == '-' || digits[0] == '+' {
+		sign, digits = digits[:1], digits[1:]
+	}
+
+	intPart := digits
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		intPart = digits[:i]
+	}
+
+	for _, r := range intPart {
+		if r < '0' || r > '9' {
+			return s
+		}
+	}
+
+	if len(intPart) >= n {
+		return s
+	}
+
+	return sign + strings.Repeat("0", n-len(intPart)) + digits
+}
+
+// roundDecimal rounds the number s to places decimal places, with halves
+// rounded away from zero. The rounding is done on the decimal value of s, so
+// "1.005" rounds up to "1.01" even though the nearest float64 is below it.
+// Values that are not numbers are returned as they are.
+func roundDecimal(s string, places int) string {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return s
+	}
+	if r, ok := new(big.Rat).SetString(s); ok {
+		return r.FloatString(places)
+	}
+	// infinities and NaN have no exact value
+	return strconv.FormatFloat(f, 'f', places, 64)
+}
+
+// groupThousands inserts sep between each group of three digits in the
+// integer part of the decimal number s, counting from the decimal point.
+// Values that are not plain decimal numbers are returned unchanged.
+func groupThousands(s string, sep rune) string {
+	if _, err := strconv.ParseFloat(s, 64); err != nil {
+		return s
+	}
+
+	sign, digits := "", s
+	if digits[0] == '-' || digits[0] == '+' {
+		sign, digits = digits[:1], digits[1:]
+	}
+
+	intPart, frac := digits, ""
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		intPart, frac = digits[:i], digits[i:]
+	}
+
+	for _, r := range intPart {
+		if r < '0' || r > '9' {
+			return s
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(sign)
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			sb.WriteRune(sep)
+		}
+		sb.WriteRune(r)
+	}
+	sb.WriteString(frac)
+	return sb.String()
+}
+
+// urlPattern matches the http and https URLs linked by WithAutoLinkURLs.
+var urlPattern = regexp.MustCompile(`https?://[^\s]+`)
+
+// linkURLs wraps each URL in s in an OSC 8 hyperlink escape sequence.
+func linkURLs(s string) string {
+	return urlPattern.ReplaceAllStringFunc(s, func(url string) string {
+		return "\x1b]8;;" + url + "\x1b\\" + url + "\x1b]8;;\x1b\\"
+	})
+}
+
+// whitespaceMarker replaces leading and trailing whitespace when
+// WithVisibleWhitespace is enabled.
+const whitespaceMarker = "·"
+
+// visibleWhitespace replaces the leading and trailing spaces and tabs on each
+// line of s with whitespaceMarker.
+func visibleWhitespace(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " \t")
+		lead := len(line) - len(trimmed)
+		trimmed = strings.TrimRight(trimmed, " \t")
+		trail := len(line) - lead - len(trimmed)
+		if len(trimmed) == 0 {
+			lead, trail = len(line), 0
+		}
+		lines[i] = strings.Repeat(whitespaceMarker, lead) + trimmed + strings.Repeat(whitespaceMarker, trail)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseBoolish parses s as a boolean, accepting the values understood by
+// strconv.ParseBool as well as common words such as "yes" and "off".
+func parseBoolish(s string) (value, ok bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "1", "t", "true", "y", "yes", "on":
+		return true, true
+	case "0", "f", "false", "n", "no", "off":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// boolTexts holds the display text for true and false values set with
+// WithBoolNormalize.
+type boolTexts struct {
+	True, False string
+}
+
+func (bt boolTexts) text(b bool) string {
+	if b {
+		return bt.True
+	}
+	return bt.False
+}
+
+func copyIntMap(m map[int]int) map[int]int {
+	if m == nil {
+		return nil
+	}
+	out := make(map[int]int, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+func copyStringMap(m map[int]string) map[int]string {
+	if m == nil {
+		return nil
+	}
+	out := make(map[int]string, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// copyRows returns a deep copy of rows, keeping nil rows nil.
+func copyRows(rows [][]string) [][]string {
+	out := make([][]string, len(rows))
+	for i, row := range rows {
+		if row != nil {
+			out[i] = append([]string(nil), row...)
+		}
+	}
+	return out
+}
+
 func safeOffset(sarr []string, idx int) string {
-	if idx >= len(sarr) {
+	if idx < 0 || idx >= len(sarr) {
 		return ""
 	}
 	return sarr[idx]
@@ -3,14 +3,19 @@ package table
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/mattn/go-runewidth"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/unicode/norm"
 )
 
 func TestFormatter(t *testing.T) {
@@ -90,6 +95,40 @@ func TestTable_WithFirstColumnFormatter(t *testing.T) {
 	assert.Contains(t, out, "buzz")
 }
 
+func TestTable_WithColumnFormatter(t *testing.T) {
+	t.Parallel()
+
+	wrap := func(open, close string) Formatter {
+		return func(f string, v ...interface{}) string {
+			return open + fmt.Sprintf(f, v...) + close
+		}
+	}
+
+	buf := bytes.Buffer{}
+	tbl := New("Host", "Status", "Note").
+		WithWriter(&buf).
+		WithPadding(1).
+		WithFirstColumnFormatter(wrap("<", ">")).
+		WithColumnFormatter(0, wrap("(", ")")).
+		WithColumnFormatter(1, wrap("[", "]")).
+		WithColumnFormatter(5, wrap("{", "}")).
+		WithRowColorCycle([]Formatter{wrap("*", "*")}).
+		AddRow("db", "up", "ok")
+
+	tbl.Print()
+	assert.Equal(t, "Host Status Note \n*(<db   >)**[up     ]**ok   *\n", buf.String())
+
+	// nil removes the formatter
+	buf.Reset()
+	tbl.WithColumnFormatter(1, nil).WithColumnFormatter(0, nil).WithRowColorCycle(nil).Print()
+	assert.Equal(t, "Host Status Note \n<db   >up     ok   \n", buf.String())
+
+	// negative columns are ignored
+	buf.Reset()
+	tbl.WithColumnFormatter(-1, wrap("[", "]")).Print()
+	assert.Equal(t, "Host Status Note \n<db   >up     ok   \n", buf.String())
+}
+
 func TestTable_WithPadding(t *testing.T) {
 	t.Parallel()
 
@@ -136,6 +175,46 @@ func TestTable_WithWriter(t *testing.T) {
 	assert.NotEmpty(t, out)
 }
 
+func TestTable_WithValueStringer(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("Name", "Joined", "Score").
+		WithWriter(&buf).
+		WithPlainMode(true).
+		WithValueStringer(func(v interface{}) (string, bool) {
+			switch v := v.(type) {
+			case time.Time:
+				return v.Format("2006-01-02"), true
+			case float64:
+				return strconv.FormatFloat(v, 'f', 1, 64), true
+			}
+			return "", false
+		})
+
+	joined := time.Date(2020, 3, 4, 5, 6, 7, 0, time.UTC)
+	tbl.AddRow("alice", joined, 9.25).
+		AddTreeRow(1, joined, "bob", 7).
+		Print()
+	assert.Equal(t, "Name\tJoined\tScore\nalice\t2020-03-04\t9.2\n  2020-03-04\tbob\t7\n", buf.String())
+
+	// nil restores fmt.Sprint
+	buf.Reset()
+	tbl.WithValueStringer(nil).SetRows(nil).AddRow("carol", 1.5).Print()
+	assert.Equal(t, "Name\tJoined\tScore\ncarol\t1.5\t\n", buf.String())
+}
+
+func TestTable_AddWriter(t *testing.T) {
+	t.Parallel()
+
+	first, second := bytes.Buffer{}, bytes.Buffer{}
+	tbl := New("foo", "bar").WithWriter(&first).AddWriter(&second).AddWriter(nil)
+	tbl.AddRow("fizz", "buzz").Print()
+
+	assert.Equal(t, "foo   bar   \nfizz  buzz  \n", first.String())
+	assert.Equal(t, first.String(), second.String())
+}
+
 func TestTable_AddRow(t *testing.T) {
 	t.Parallel()
 
@@ -292,6 +371,89 @@ func TestTable_SetRows(t *testing.T) {
 	assert.NotContains(t, buf.String(), "boo")
 }
 
+func TestTable_SetCell(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("ID", "Name", "Total").WithWriter(&buf).
+		AddRow(1, "foo", 3).
+		AddSeparatorRow().
+		AddRow(2)
+
+	assert.NoError(t, tbl.SetCell(0, 2, "4"))
+	assert.NoError(t, tbl.SetCell(2, 1, "bar"))
+	tbl.Print()
+	expected := `ID  Name  Total  
+1   foo   4      
+-----------------
+2   bar          
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// out of range or separator rows
+	assert.Error(t, tbl.SetCell(-1, 0, "x"))
+	assert.Error(t, tbl.SetCell(3, 0, "x"))
+	assert.Error(t, tbl.SetCell(0, -1, "x"))
+	assert.Error(t, tbl.SetCell(0, 3, "x"))
+	assert.Error(t, tbl.SetCell(1, 0, "x"))
+}
+
+func TestTable_UpdateCell(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("ID", "Name", "Total").WithWriter(&buf).
+		AddRow(1, "foo", 3).
+		AddRow(2)
+
+	assert.NoError(t, tbl.UpdateCell(0, 2, 4.5))
+	assert.NoError(t, tbl.UpdateCell(1, 1, "bar\nbaz"))
+	tbl.Print()
+	expected := `ID  Name  Total  
+1   foo   4.5    
+2   bar          
+    baz          
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// out of range
+	assert.Error(t, tbl.UpdateCell(2, 0, "x"))
+	assert.Error(t, tbl.UpdateCell(0, 3, "x"))
+}
+
+func TestTable_RemoveRow(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("ID", "Name").WithWriter(&buf).
+		AddRow(1, "foo").
+		AddSeparatorRow().
+		AddRowAligned([]Alignment{AlignRight}, 2, "bar").
+		AddRow(3, "baz")
+
+	assert.NoError(t, tbl.RemoveRow(1))
+	assert.NoError(t, tbl.RemoveRow(0))
+	tbl.Print()
+	expected := `ID  Name  
+ 2  bar   
+3   baz   
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// out of range leaves the table unchanged
+	assert.Error(t, tbl.RemoveRow(2))
+	assert.Error(t, tbl.RemoveRow(-1))
+	buf.Reset()
+	tbl.Print()
+	assert.Equal(t, expected, buf.String())
+}
+
 func TestTable_WithWidthFunc(t *testing.T) {
 	t.Parallel()
 
@@ -309,3 +471,1917 @@ func TestTable_WithWidthFunc(t *testing.T) {
 	assert.Contains(t, actual, "请求 alpha")
 	assert.Contains(t, actual, "abc  beta")
 }
+
+func TestTable_WithZeroPad(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("ID", "Name").
+		WithWriter(&buf).
+		WithZeroPad(0, 3).
+		AddRow(7, "foo").
+		AddRow("-12", "bar").
+		AddRow(3.5, "baz").
+		AddRow(12345, "qux").
+		AddRow("n/a", "quux")
+
+	tbl.Print()
+	expected := `ID     Name  
+007    foo   
+-012   bar   
+003.5  baz   
+12345  qux   
+n/a    quux  
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// non-positive digits disable padding
+	buf.Reset()
+	tbl.WithZeroPad(0, 0).Print()
+	assert.Contains(t, buf.String(), "\n7 ")
+}
+
+func TestTable_WithColumnTransform(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("ID", "Name").
+		WithWriter(&buf).
+		WithColumnTransform(1, func(s string) string { return "<" + s + ">" }).
+		WithColumnTransform(0, strings.TrimSpace).
+		WithZeroPad(0, 2).
+		AddRow(" 1 ", "foo").
+		AddRow("22", "barbaz")
+
+	tbl.Print()
+	expected := `ID  Name      
+01  <foo>     
+22  <barbaz>  
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// nil removes the transform
+	buf.Reset()
+	tbl.WithColumnTransform(1, nil).Print()
+	assert.NotContains(t, buf.String(), "<")
+}
+
+func TestTable_WithPlainMode(t *testing.T) {
+	t.Parallel()
+
+	uppercase := func(f string, v ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(f, v...))
+	}
+
+	buf := bytes.Buffer{}
+	tbl := New("foo", "bar").
+		WithWriter(&buf).
+		WithHeaderFormatter(uppercase).
+		WithFirstColumnFormatter(uppercase).
+		WithHeaderSeparatorRow('-').
+		WithPlainMode(true).
+		AddRow("fizz", "buzz").
+		AddRow("cat")
+
+	tbl.Print()
+	expected := "foo\tbar\nfizz\tbuzz\ncat\t\n"
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// disabling restores the decorated output
+	buf.Reset()
+	tbl.WithPlainMode(false).Print()
+	assert.Contains(t, buf.String(), "FOO")
+	assert.Contains(t, buf.String(), "---")
+	// header icons are decoration too
+	buf.Reset()
+	New("A", "B").WithWriter(&buf).WithColumnHeaderIcon(1, "*").WithPlainMode(true).Print()
+	assert.Equal(t, "A\tB\n", buf.String())
+}
+
+func TestTable_WithBoolNormalize(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("Name", "Admin").
+		WithWriter(&buf).
+		WithBoolNormalize(1, "Yes", "No").
+		AddRow("a", "TRUE").
+		AddRow("b", 1).
+		AddRow("c", "yes").
+		AddRow("d", false).
+		AddRow("e", "Off").
+		AddRow("f", "maybe")
+
+	tbl.Print()
+	expected := `Name  Admin  
+a     Yes    
+b     Yes    
+c     Yes    
+d     No     
+e     No     
+f     maybe  
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// empty texts remove the normalization
+	buf.Reset()
+	tbl.WithBoolNormalize(1, "", "").Print()
+	assert.Contains(t, buf.String(), "TRUE")
+}
+
+func TestTable_WithLineNumbers(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("foo", "bar").WithWriter(&buf).WithLineNumbers(true).WithHeaderSeparatorRow('-')
+	for i := 0; i < 10; i++ {
+		tbl.AddRow("fizz", i)
+	}
+
+	tbl.Print()
+	expected := ` #  foo   bar  
+ -  ---   ---  
+ 1  fizz  0    
+ 2  fizz  1    
+ 3  fizz  2    
+ 4  fizz  3    
+ 5  fizz  4    
+ 6  fizz  5    
+ 7  fizz  6    
+ 8  fizz  7    
+ 9  fizz  8    
+10  fizz  9    
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// disabled by default
+	buf.Reset()
+	tbl.WithLineNumbers(false).Print()
+	assert.True(t, strings.HasPrefix(buf.String(), "foo"))
+}
+
+func TestTable_WithThresholdColoring(t *testing.T) {
+	t.Parallel()
+
+	wrap := func(tag string) Formatter {
+		return func(f string, v ...interface{}) string {
+			return "<" + tag + ">" + fmt.Sprintf(f, v...) + "</" + tag + ">"
+		}
+	}
+
+	buf := bytes.Buffer{}
+	tbl := New("Host", "Errors").
+		WithWriter(&buf).
+		WithThresholdColoring(1, []Threshold{
+			{Value: 100, Formatter: wrap("r")},
+			{Value: 0, Formatter: wrap("g")},
+			{Value: 10, Formatter: wrap("y")},
+		}).
+		AddRow("a", 0).
+		AddRow("b", 42).
+		AddRow("c", 100).
+		AddRow("d", -1).
+		AddRow("e", "n/a")
+
+	tbl.Print()
+	expected := `Host  Errors  
+<g>a     </g><g>0       </g>
+<y>b     </y><y>42      </y>
+<r>c     </r><r>100     </r>
+d     -1      
+e     n/a     
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// no thresholds removes the coloring
+	buf.Reset()
+	tbl.WithThresholdColoring(1, nil).Print()
+	assert.NotContains(t, buf.String(), "<")
+
+	// a column out of range never matches
+	buf.Reset()
+	tbl.WithThresholdColoring(-1, []Threshold{{Value: 0, Formatter: wrap("g")}}).Print()
+	assert.NotContains(t, buf.String(), "<")
+}
+
+func TestTable_Concurrency(t *testing.T) {
+	t.Parallel()
+
+	buf := lockedBuffer{}
+	tbl := New("foo", "bar").WithWriter(&buf)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			tbl.AddRow("fizz", i).WithPadding(i % 3)
+		}(i)
+		go func() {
+			defer wg.Done()
+			tbl.Print()
+		}()
+	}
+	wg.Wait()
+
+	// every Print wrote a complete table in one piece
+	assert.Len(t, buf.writes, 10)
+	for _, w := range buf.writes {
+		assert.True(t, strings.HasPrefix(w, "foo"), w)
+	}
+}
+
+// lockedBuffer records each call to Write, and is safe for concurrent use.
+type lockedBuffer struct {
+	mu     sync.Mutex
+	writes []string
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.writes = append(b.writes, string(p))
+	return len(p), nil
+}
+
+func TestTable_IsTerminal(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	assert.False(t, New().WithWriter(&buf).IsTerminal())
+
+	temp, err := ioutil.TempFile("", "")
+	assert.NoError(t, err)
+	defer os.Remove(temp.Name())
+	assert.False(t, New().WithWriter(temp).IsTerminal())
+
+	// closed files cannot be inspected
+	temp.Close()
+	assert.False(t, New().WithWriter(temp).IsTerminal())
+
+	// character devices other than terminals are not terminals
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	assert.NoError(t, err)
+	defer devNull.Close()
+	assert.False(t, New().WithWriter(devNull).IsTerminal())
+
+	assert.True(t, New().WithWriter(&terminalBuffer{}).IsTerminal())
+}
+
+func TestTable_AddTreeRow(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("Package", "Version").
+		WithWriter(&buf).
+		AddTreeRow(0, "app", "1.0").
+		AddTreeRow(1, "lib", "2.3").
+		AddTreeRow(2, "dep\nother", "0.1").
+		WithTreeIndent("└─ ").
+		AddTreeRow(1, "tool", "4.5").
+		AddTreeRow(-1, "root").
+		AddTreeRow(3)
+
+	tbl.Print()
+	expected := `Package    Version  
+app        1.0      
+  lib      2.3      
+    dep    0.1      
+    other           
+└─ tool    4.5      
+root                
+                    
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+}
+
+func TestTable_WithColumnHeaderSeparatorRune(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("ID", "Name", "Cost").
+		WithWriter(&buf).
+		WithHeaderSeparatorRow('-').
+		WithColumnHeaderSeparatorRune(0, '=').
+		AddRow(1, "foo", 2)
+
+	tbl.Print()
+	expected := `ID  Name  Cost  
+==  ----  ----  
+1   foo   2     
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// without a global rune, only the overridden columns get a separator
+	buf.Reset()
+	tbl.WithHeaderSeparatorRow(0).Print()
+	assert.Contains(t, buf.String(), "\n==              \n")
+
+	// a zero rune removes the override
+	buf.Reset()
+	tbl.WithColumnHeaderSeparatorRune(0, 0).Print()
+	assert.Equal(t, 2, strings.Count(buf.String(), "\n"))
+}
+
+func TestTable_WithColumnGroupBoundaries(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("Name", "Q1", "Q2", "Q3").
+		WithWriter(&buf).
+		WithHeaderSeparatorRow('-').
+		WithColumnGroupBoundaries([]int{0, 2}, "|% ").
+		AddRow("foo", 1, 2, 3).
+		AddRow("barbaz", 4, 5, 6)
+
+	tbl.Print()
+	expected := `Name    |% Q1  Q2  |% Q3  
+----    |% --  --  |% --  
+foo     |% 1   2   |% 3   
+barbaz  |% 4   5   |% 6   
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// no columns removes the boundaries
+	buf.Reset()
+	tbl.WithColumnGroupBoundaries(nil, "|").Print()
+	assert.NotContains(t, buf.String(), "|")
+}
+
+func TestTable_AddRowf(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("ID", "Name", "Cost").
+		WithWriter(&buf).
+		AddRowf("%03d\t%s\t$%.2f", 7, "Foobar", 1.5).
+		AddRowf("%d\t%s", 8, "multi\nline").
+		AddRowf("only").
+		AddRowf("a\tb\tc\td")
+
+	tbl.Print()
+	expected := `ID    Name    Cost   
+007   Foobar  $1.50  
+8     multi          
+      line           
+only                 
+a     b       c      
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+}
+
+func TestTable_WithSeparatorJunction(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("Account", "Debit", "Credit").
+		WithWriter(&buf).
+		WithHeaderSeparatorRow('-').
+		WithColumnRightBorder(0, '|').
+		WithColumnGroupBoundaries([]int{1}, " % ").
+		WithSeparatorJunction('+').
+		AddRow("cash", 100, "").
+		AddSeparatorRow().
+		AddRow("sales", "", 100)
+
+	tbl.Print()
+	expected := `Account  | Debit   % Credit  
+---------+---------+---------
+cash     | 100     %         
+---------+---------+---------
+sales    |         % 100     
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// a zero rune restores the separators
+	buf.Reset()
+	tbl.WithSeparatorJunction(0).Print()
+	assert.Contains(t, buf.String(), "-------  | -----   % ------  \n")
+}
+
+func TestTable_WithStyle(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("ID", "Name").
+		WithWriter(&buf).
+		WithPadding(1).
+		WithStyle(StyleRounded).
+		AddRow(1, "foo").
+		AddSeparatorRow().
+		AddRow(22, "")
+
+	// AddRow splits values on newlines, so set a multi-line cell directly
+	assert.NoError(t, tbl.SetCell(2, 1, "bar\nbaz"))
+	tbl.Print()
+	expected := `╭────┬──────╮
+│ ID │ Name │
+├────┼──────┤
+│ 1  │ foo  │
+├────┼──────┤
+│ 22 │ bar  │
+│    │ baz  │
+╰────┴──────╯
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// row separators are not doubled up with separator rows
+	buf.Reset()
+	style := StyleASCII
+	style.RowSeparators = true
+	tbl.WithStyle(style).AddRow(3, "qux").WithLineNumbers(true).Print()
+	expected = `  +----+------+
+# | ID | Name |
+  +----+------+
+1 | 1  | foo  |
+  +----+------+
+2 | 22 | bar  |
+  |    | baz  |
+  +----+------+
+3 | 3  | qux  |
+  +----+------+
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	buf.Reset()
+	New("ID", "Name").WithWriter(&buf).WithPadding(1).WithStyle(StyleMarkdown).AddRow(1, "foo").Print()
+	expected = `| ID | Name |
+|----|------|
+| 1  | foo  |
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// the panel fits around the style
+	buf.Reset()
+	tbl.WithLineNumbers(false).WithStyle(StyleDouble).WithPanel("T").Print()
+	assert.Contains(t, buf.String(), "│ ║ 1  ║ foo  ║ │\n")
+
+	// the empty style removes it
+	buf.Reset()
+	tbl.WithPanel("").WithStyle(TableStyle{}).Print()
+	assert.Contains(t, buf.String(), "ID Name \n1  foo  \n")
+}
+
+func TestTable_WithColumnHeaderIcon(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("Host", "Latency").
+		WithWriter(&buf).
+		WithWidthFunc(runewidth.StringWidth).
+		WithHeaderSeparatorRow('-').
+		WithColumnHeaderIcon(1, "⏱").
+		WithColumnHeaderIcon(0, "🔥").
+		AddRow("db", "12ms")
+
+	tbl.Print()
+	expected := `🔥 Host  ⏱ Latency  
+-------  ---------  
+db       12ms       
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// an empty icon removes it
+	buf.Reset()
+	tbl.WithColumnHeaderIcon(0, "").Print()
+	assert.True(t, strings.HasPrefix(buf.String(), "Host"))
+}
+
+func TestTable_WithRowColorCycle(t *testing.T) {
+	t.Parallel()
+
+	wrap := func(tag string) Formatter {
+		return func(f string, v ...interface{}) string {
+			return "<" + tag + ">" + fmt.Sprintf(f, v...) + "</" + tag + ">"
+		}
+	}
+
+	buf := bytes.Buffer{}
+	tbl := New("foo", "bar").
+		WithWriter(&buf).
+		WithRowColorCycle([]Formatter{wrap("a"), nil, wrap("c")}).
+		WithThresholdColoring(1, []Threshold{{Value: 10, Formatter: wrap("t")}})
+	for i := 0; i < 4; i++ {
+		tbl.AddRow("x", i)
+	}
+	tbl.AddRow("y", 10)
+
+	tbl.Print()
+	expected := `foo  bar  
+<a>x    </a><a>0    </a>
+x    1    
+<c>x    </c><c>2    </c>
+<a>x    </a><a>3    </a>
+<t>y    </t><t>10   </t>
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// no formatters removes the cycle
+	buf.Reset()
+	tbl.WithRowColorCycle(nil).Print()
+	assert.NotContains(t, buf.String(), "<a>")
+}
+
+func TestTable_WithColumnAlignment(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("Item", "Cost", "Note").
+		WithWriter(&buf).
+		WithHeaderSeparatorRow('-').
+		WithColumnAlignment(AlignLeft, AlignRight, AlignCenter).
+		AddRow("pen", "1.50", "x").
+		AddRow("ink", "12.00", "long note").
+		AddRowAligned([]Alignment{AlignLeft, AlignLeft}, "cap", "-", "y")
+
+	tbl.Print()
+	expected := `Item   Cost    Note     
+----   ----    ----     
+pen    1.50      x      
+ink   12.00  long note  
+cap   -          y      
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// no alignments restores the default
+	buf.Reset()
+	tbl.WithColumnAlignment().Print()
+	assert.Contains(t, buf.String(), "pen   1.50   x          \n")
+}
+
+func TestTable_AddRowAligned(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("Name", "Score", "Rank").
+		WithWriter(&buf).
+		AddRow("alice", 12345, 1).
+		AddRowAligned([]Alignment{AlignLeft, AlignCenter, AlignRight}, "bob", "-", 2).
+		AddRowAligned([]Alignment{AlignRight}, "x", "1\n2").
+		AddRow("carol", 7, 3)
+
+	tbl.Print()
+	expected := `Name   Score  Rank  
+alice  12345  1     
+bob      -       2  
+    x  1            
+       2            
+carol  7      3     
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// alignments follow their rows into derived tables
+	buf.Reset()
+	tbl.SplitByColumn(0)["bob"].Print()
+	assert.Contains(t, buf.String(), "bob     -       2  ")
+
+	// SetRows discards them
+	buf.Reset()
+	tbl.SetRows([][]string{{"bob", "-", "2"}}).Print()
+	assert.Contains(t, buf.String(), "bob   -      2     ")
+}
+
+func TestTable_WithThousandsSeparator(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("City", "Population").
+		WithWriter(&buf).
+		WithThousandsSeparator(1, ',').
+		AddRow("Paris", 2102650).
+		AddRow("Lyon", 522250.75).
+		AddRow("Nice", -342).
+		AddRow("Sète", "+4000").
+		AddRow("Mars", "1e6").
+		AddRow("Moon", "n/a")
+
+	tbl.Print()
+	expected := `City   Population  
+Paris  2,102,650   
+Lyon   522,250.75  
+Nice   -342        
+Sète   +4,000      
+Mars   1e6         
+Moon   n/a         
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// decimal places are applied first
+	buf.Reset()
+	tbl.WithColumnDecimalPlaces(1, 0).WithThousandsSeparator(1, '.').WithPlainMode(true).Print()
+	assert.Contains(t, buf.String(), "Lyon\t522.251\n")
+
+	// a zero rune removes the separator
+	buf.Reset()
+	tbl.WithThousandsSeparator(1, 0).Print()
+	assert.Contains(t, buf.String(), "Paris\t2102650\n")
+}
+
+func TestTable_WithSmartAlignment(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("Item", "Cost").
+		WithWriter(&buf).
+		WithSmartAlignment(1, true).
+		WithThousandsSeparator(1, ',').
+		AddRow("pen", 1.5).
+		AddRow("ink", 1200).
+		AddRow("cap", "free").
+		AddRowAligned([]Alignment{AlignLeft, AlignCenter}, "box", 3)
+
+	tbl.Print()
+	expected := `Item  Cost   
+pen     1.5  
+ink   1,200  
+cap   free   
+box     3    
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// disabling restores the column alignment
+	buf.Reset()
+	tbl.WithSmartAlignment(1, false).Print()
+	assert.Contains(t, buf.String(), "pen   1.5    \n")
+}
+
+func TestTable_WithColumnDecimalPlaces(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("Item", "Cost").
+		WithWriter(&buf).
+		WithColumnDecimalPlaces(1, 2).
+		AddRow("pen", 1.256).
+		AddRow("ink", 3).
+		AddRow("cap", "-0.004").
+		AddRow("box", 1234.5).
+		AddRow("bag", "free").
+		AddRow("tin", 1.005).
+		AddRow("jar", "-2.675")
+
+	tbl.Print()
+	expected := `Item  Cost     
+pen   1.26     
+ink   3.00     
+cap   -0.00    
+box   1234.50  
+bag   free     
+tin   1.01     
+jar   -2.68    
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// zero places rounds to whole numbers
+	buf.Reset()
+	tbl.WithColumnDecimalPlaces(1, 0).Print()
+	assert.Contains(t, buf.String(), "box   1235")
+	assert.NotContains(t, buf.String(), "1235.")
+
+	// negative places removes the rounding
+	buf.Reset()
+	tbl.WithColumnDecimalPlaces(1, -1).Print()
+	assert.Contains(t, buf.String(), "1.256")
+}
+
+func TestTable_WithColumnSubfields(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("Pod", "Labels", "Age").
+		WithWriter(&buf).
+		WithLineNumbers(true).
+		WithColumnSubfields(1, ";").
+		AddRow("web", "app=web; tier=frontend", "1d").
+		AddRow("db", "app=db", "2d")
+
+	tbl.Print()
+	expected := `#  Pod  Labels         Age  
+1  web  app=web        1d   
+        tier=frontend       
+2  db   app=db         2d   
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// plain mode puts subfields on their own lines too
+	buf.Reset()
+	tbl.WithPlainMode(true).Print()
+	assert.Equal(t, "Pod\tLabels\tAge\nweb\tapp=web\t1d\n\ttier=frontend\t\ndb\tapp=db\t2d\n", buf.String())
+
+	// an empty separator removes the splitting
+	buf.Reset()
+	tbl.WithPlainMode(false).WithColumnSubfields(1, "").Print()
+	assert.Contains(t, buf.String(), "app=web; tier=frontend")
+}
+
+func TestTable_WithOutlierWidthCap(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("ID", "Path").
+		WithWriter(&buf).
+		WithOutlierWidthCap(1, 50).
+		AddRow(1, "/bin").
+		AddRow(2, "/usr").
+		AddRow(3, "/usr/local/share")
+
+	tbl.Print()
+	expected := `ID  Path  
+1   /bin  
+2   /usr  
+3   /usr  
+    /loc  
+    al/s  
+    hare  
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// the column is never narrower than its header
+	buf.Reset()
+	New("ID", "Location").WithWriter(&buf).WithOutlierWidthCap(1, 10).
+		AddRow(1, "a").AddRow(2, "somewhere far").Print()
+	assert.Contains(t, buf.String(), "2   somewher  \n    e far     \n")
+
+	// out of range percentiles remove the cap
+	buf.Reset()
+	tbl.WithOutlierWidthCap(1, 100).Print()
+	assert.Contains(t, buf.String(), "3   /usr/local/share  \n")
+
+	// negative columns are ignored
+	buf.Reset()
+	tbl.WithOutlierWidthCap(-1, 50).Print()
+	assert.Contains(t, buf.String(), "3   /usr/local/share  \n")
+
+	// wrapped lines are continued with the marker
+	buf.Reset()
+	tbl.WithOutlierWidthCap(1, 50).WithWrapContinuationMarker("+").Print()
+	assert.Contains(t, buf.String(), "3   /usr  \n    +/lo  \n    +cal  \n")
+}
+
+func TestTable_WithMaxColumnWidth(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("Key", "Value", "Note").
+		WithWriter(&buf).
+		WithMaxColumnWidth(0, 4, 3).
+		AddRow("a", "abcdefghijk", "xyz12").
+		AddRow("b", "short", "ok")
+
+	// the columns are never narrower than their headers, and the row is as
+	// tall as its most wrapped cell
+	tbl.Print()
+	expected := `Key  Value  Note  
+a    abcde  xyz1  
+     fghij  2     
+     k            
+b    short  ok    
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// widths set by SetColumnWidths are clamped to the cap
+	buf.Reset()
+	tbl.SetColumnWidths([]int{0, 8}).Print()
+	assert.Contains(t, buf.String(), "a    abcde  xyz1  \n")
+
+	// removing the caps
+	buf.Reset()
+	tbl.WithMaxColumnWidth().Print()
+	assert.Contains(t, buf.String(), "a    abcdefghijk  xyz12  \n")
+
+	// wrapped lines are continued with the marker, which fits within the cap
+	buf.Reset()
+	New("Key", "Value").WithWriter(&buf).WithMaxColumnWidth(0, 5).WithWrapContinuationMarker("> ").
+		AddRow("a", "abcdefghij").Print()
+	assert.Equal(t, "Key  Value  \na    abcde  \n     > fgh  \n     > ij   \n", buf.String())
+}
+
+func TestTable_WithRunningTotal(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("Date", "Amount").
+		WithWriter(&buf).
+		WithRunningTotal(1, "Balance").
+		AddRow("05-01", 100).
+		AddRow("05-02", -25.5).
+		AddRow("05-03", "n/a").
+		AddRow("05-04", 10)
+
+	tbl.Print()
+	expected := `Date   Amount  Balance  
+05-01  100     100      
+05-02  -25.5   74.5     
+05-03  n/a     74.5     
+05-04  10      84.5     
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// totals follow the current rows
+	buf.Reset()
+	tbl.SetRows([][]string{{"06-01", "0.25"}, {"06-02", "0.5"}, {"06-03"}})
+	tbl.Print()
+	expected = `Date   Amount  Balance  
+06-01  0.25    0.25     
+06-02  0.5     0.75     
+06-03          0.75     
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// an empty header removes the column
+	buf.Reset()
+	tbl.WithRunningTotal(1, "").Print()
+	assert.NotContains(t, buf.String(), "Balance")
+
+	// negative source columns are ignored
+	buf.Reset()
+	tbl.WithRunningTotal(-1, "Balance").Print()
+	assert.NotContains(t, buf.String(), "Balance")
+}
+
+func TestTable_WithVisibleWhitespace(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("Key", "Value").
+		WithWriter(&buf).
+		WithVisibleWhitespace(true).
+		AddRow("name", " alice  ").
+		AddRow("tab", "\tx y").
+		AddRow("blank", "  ")
+
+	tbl.Print()
+	expected := `Key    Value     
+name   ·alice··  
+tab    ·x y      
+blank  ··        
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// plain mode keeps the original whitespace
+	buf.Reset()
+	tbl.WithPlainMode(true).Print()
+	assert.Contains(t, buf.String(), "name\t alice  \n")
+}
+
+func TestTable_WithAutoLinkURLs(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("Name", "Docs").
+		WithWriter(&buf).
+		WithAutoLinkURLs(true).
+		AddRow("go", "see https://go.dev now").
+		AddRow("none", "n/a")
+
+	tbl.Print()
+	expected := "Name  Docs                    \n" +
+		"go    see \x1b]8;;https://go.dev\x1b\\https://go.dev\x1b]8;;\x1b\\ now  \n" +
+		"none  n/a                     \n"
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// plain mode prints the URL as is
+	buf.Reset()
+	tbl.WithPlainMode(true).Print()
+	assert.NotContains(t, buf.String(), "\x1b")
+}
+
+func TestTable_WithLineRenderer(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("ID", "Name").
+		WithWriter(&buf).
+		WithHeaderSeparatorRow('-').
+		WithColumnGroupBoundaries([]int{0}, "!").
+		WithLineRenderer(func(cells []string) string {
+			return "| " + strings.Join(cells, "| ") + "|"
+		}).
+		AddRow(1, "foo")
+
+	tbl.Print()
+	expected := `| ID  | Name  |
+| --  | ----  |
+| 1   | foo   |
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// the header formatter applies to the rendered line
+	buf.Reset()
+	tbl.WithHeaderFormatter(func(format string, vals ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(format, vals...))
+	}).WithHeaderSeparatorRow(0).Print()
+	assert.Equal(t, "| ID  | NAME  |\n| 1   | foo   |\n", buf.String())
+
+	// nil restores the default
+	buf.Reset()
+	tbl.WithLineRenderer(nil).Print()
+	assert.Equal(t, "ID  !NAME  \n1   !foo   \n", buf.String())
+}
+
+func TestTable_WithHeaderAtBottom(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("ID", "Name").
+		WithWriter(&buf).
+		WithHeaderAtBottom(true).
+		WithHeaderSeparatorRow('-').
+		AddRow(1, "foo").
+		AddRow(2, "bar")
+
+	tbl.Print()
+	expected := `ID  Name  
+--  ----  
+1   foo   
+2   bar   
+--  ----  
+ID  Name  
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// plain mode prints the header once
+	buf.Reset()
+	tbl.WithPlainMode(true).Print()
+	assert.Equal(t, "ID\tName\n1\tfoo\n2\tbar\n", buf.String())
+}
+
+func TestTable_AddFooter(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("Item", "Cost").
+		WithWriter(&buf).
+		WithFooterSeparatorRow('=').
+		AddRow("pen", 1).
+		AddRow("ink", 3).
+		AddFooter("grand total", 4, "dropped")
+
+	// footer cells size the columns, and extra values are truncated
+	tbl.Print()
+	expected := `Item         Cost  
+pen          1     
+ink          3     
+===================
+grand total  4     
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// the footer is only printed on the last page, and is not numbered
+	buf.Reset()
+	tbl.WithLineNumbers(true).PrintPage(0, 1)
+	assert.NotContains(t, buf.String(), "grand total")
+	buf.Reset()
+	tbl.PrintPage(1, 1)
+	assert.Contains(t, buf.String(), "\n   grand total  4     \n")
+
+	// short footers are padded, and plain mode prints the footer last
+	buf.Reset()
+	tbl.AddFooter("total").WithLineNumbers(false).WithPlainMode(true).Print()
+	assert.Equal(t, "Item\tCost\npen\t1\nink\t3\ntotal\t\n", buf.String())
+
+	// footers of tables with boolean symbols are read as they were added
+	term := terminalBuffer{}
+	New("Name", "Active").WithWriter(&term).WithBoolSymbols(true).
+		AddRow("alice", true).AddRow("bob", false).AddFooter("all", false).Print()
+	assert.Contains(t, term.String(), "all    \x1b[31m✗\x1b[0m")
+}
+
+func TestAlignWidths(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	summary := New("Team", "Total").WithWriter(&buf).
+		AddRow("platform", 12)
+	details := New("Name", "Hours", "Note").WithWriter(&buf).
+		AddRow("alice", 4, "on call").
+		AddRow("bob", 8, "")
+
+	AlignWidths(summary, details)
+	summary.Print()
+	details.Print()
+	expected := `Team      Total  
+platform  12     
+Name      Hours  Note     
+alice     4      on call  
+bob       8               
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// the widths are minimums, so wider cells still fit
+	buf.Reset()
+	summary.AddRow("infrastructure", 3).Print()
+	assert.Contains(t, buf.String(), "infrastructure  3      \n")
+
+	// footers count towards the shared widths
+	buf.Reset()
+	totals := New("Item", "Cost").WithWriter(&buf).
+		AddRow("pen", 1).AddFooter("grand total", 4)
+	items := New("Item", "Cost").WithWriter(&buf).
+		AddRow("ink", 3)
+	AlignWidths(totals, items)
+	items.Print()
+	assert.Equal(t, "Item         Cost  \nink          3     \n", buf.String())
+}
+
+func TestTable_SetColumnWidths(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("ID", "Name", "Note").
+		WithWriter(&buf).
+		WithHeaderSeparatorRow('-').
+		SetColumnWidths([]int{4, 3}).
+		AddRow(1, "foobar", "x")
+
+	// widths are minimums by default
+	tbl.Print()
+	expected := `ID    Name    Note  
+--    ----    ----  
+1     foobar  x     
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// exact widths cut wider cells short
+	buf.Reset()
+	tbl.WithExactColumnWidths(true).Print()
+	expected = `ID    Nam  Note  
+--    ---  ----  
+1     foo  x     
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// nil removes the widths
+	buf.Reset()
+	tbl.SetColumnWidths(nil).Print()
+	assert.Contains(t, buf.String(), "1   foobar  x")
+}
+
+func TestTable_GraphemeAwareCutting(t *testing.T) {
+	t.Parallel()
+
+	// with the default WidthFunc, "e\u0301" and "👍🏽" are each two wide, but
+	// are cut or wrapped as a whole rather than split apart
+	buf := bytes.Buffer{}
+	New("Word", "Emoji").
+		WithWriter(&buf).
+		SetColumnWidths([]int{4, 1}).
+		WithExactColumnWidths(true).
+		AddRow("cafe\u0301", "👍🏽").
+		Print()
+	assert.Equal(t, "Word  E  \ncaf      \n", buf.String())
+
+	buf.Reset()
+	New("W").
+		WithWriter(&buf).
+		WithPadding(1).
+		WithOutlierWidthCap(0, 50).
+		AddRow("a").
+		AddRow("ae\u0301b").
+		Print()
+	assert.Equal(t, "W  \na  \na  \ne\u0301 \nb  \n", buf.String())
+}
+
+func TestTable_WithOverflowFootnotes(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("ID", "Description").
+		WithWriter(&buf).
+		SetColumnWidths([]int{2, 6}).
+		WithExactColumnWidths(true).
+		WithOverflowFootnotes(true).
+		AddRow(1, "foobarbaz").
+		AddRow(2, "short").
+		AddRow(345, "another long one")
+
+	tbl.Print()
+	expected := `ID  Descri  
+1   foo[1]  
+2   short   
+[2  ano[3]  
+[1] foobarbaz
+[2] 345
+[3] another long one
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// footnotes are collected afresh each time
+	buf.Reset()
+	tbl.Print()
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n[1] "))
+
+	// without exact widths nothing is cut short
+	buf.Reset()
+	tbl.WithExactColumnWidths(false).Print()
+	assert.NotContains(t, buf.String(), "[1]")
+}
+
+func TestTable_Render(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("ID", "Name").
+		WithWriter(&buf).
+		WithHeaderSeparatorRow('-').
+		AddRow(1, "foo")
+
+	rendered := tbl.Render()
+	assert.Equal(t, "ID  Name  \n--  ----  \n1   foo   \n", rendered)
+	assert.Empty(t, buf.String())
+
+	tbl.Print()
+	assert.Equal(t, rendered, buf.String())
+}
+
+func TestTable_PrintErr(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("ID").WithWriter(&buf).AddRow(1)
+	assert.NoError(t, tbl.PrintErr())
+	assert.Equal(t, "ID  \n1   \n", buf.String())
+
+	w := &failingWriter{err: io.ErrClosedPipe}
+	assert.Equal(t, io.ErrClosedPipe, tbl.WithWriter(w).PrintErr())
+	assert.Equal(t, 1, w.writes)
+
+	// Print discards the error
+	tbl.Print()
+	assert.Equal(t, 2, w.writes)
+}
+
+type failingWriter struct {
+	err    error
+	writes int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return 0, w.err
+}
+
+func TestTable_Lines(t *testing.T) {
+	t.Parallel()
+
+	tbl := New("ID", "Name").
+		WithHeaderSeparatorRow('-').
+		AddRow(1, "foo\nbar").
+		AddRow(2, "baz")
+
+	assert.Equal(t, []string{
+		"ID  Name  ",
+		"--  ----  ",
+		"1   foo   ",
+		"    bar   ",
+		"2   baz   ",
+	}, tbl.Lines())
+}
+
+func TestTable_WithWrapContinuationMarker(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("Pod", "Labels").
+		WithWriter(&buf).
+		WithColumnSubfields(1, ";").
+		WithWrapContinuationMarker("↪ ").
+		AddRow("web", "app=web; tier=frontend").
+		AddRow("db", "app=db")
+
+	tbl.Print()
+	expected := `Pod  Labels           
+web  app=web          
+     ↪ tier=frontend  
+db   app=db           
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// plain mode is left alone
+	buf.Reset()
+	tbl.WithPlainMode(true).Print()
+	assert.NotContains(t, buf.String(), "↪")
+}
+
+func TestTable_WithUnitColumn(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("Op", "Latency", "Size").
+		WithWriter(&buf).
+		WithUnitColumn(1).
+		WithUnitColumn(2).
+		AddRow("get", "12 ms", "3.4GB").
+		AddRow("put", "1.5 s", 120).
+		AddRow("del", "n/a", "-2 KB")
+
+	tbl.Print()
+	expected := `Op   Latency  Size    
+get   12 ms   3.4 GB  
+put  1.5 s    120     
+del  n/a       -2 KB  
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// negative columns are ignored
+	buf.Reset()
+	tbl.WithUnitColumn(-1).Print()
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestTable_WithNoTruncate(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("ID", "Name").
+		WithWriter(&buf).
+		WithNoTruncate(true).
+		AddRow(1, "foo").
+		AddRow(2, "bar", "extra")
+
+	tbl.Print()
+	expected := `ID  Name         
+1   foo          
+2   bar   extra  
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+	assert.Len(t, tbl.Schema(), 3)
+
+	// SetRows grows the table too
+	buf.Reset()
+	tbl.SetRows([][]string{{"3", "baz", "a", "b"}})
+	tbl.WithPlainMode(true).Print()
+	assert.Equal(t, "ID\tName\t\t\n3\tbaz\ta\tb\n", buf.String())
+
+	// truncation is the default
+	buf.Reset()
+	New("ID").WithWriter(&buf).WithPlainMode(true).AddRow(1, "foo").Print()
+	assert.Equal(t, "ID\n1\n", buf.String())
+}
+
+func TestTable_WithUniqueHeaders(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("Name", "Name", "Name_2", "", "").
+		WithWriter(&buf).
+		WithPlainMode(true).
+		WithUniqueHeaders(true)
+
+	tbl.Print()
+	assert.Equal(t, "Name\tName_3\tName_2\t\t\n", buf.String())
+
+	// joined tables are renamed too
+	buf.Reset()
+	users := New("id", "name").WithWriter(&buf).WithPlainMode(true).WithUniqueHeaders(true).AddRow(1, "alice")
+	pets := New("owner", "name").AddRow(1, "rex")
+	joined, err := users.JoinOn(pets, 0, 0, InnerJoin)
+	assert.NoError(t, err)
+	joined.Print()
+	assert.Equal(t, "id\tname\tname_2\n1\talice\trex\n", buf.String())
+
+	// as are promoted headers
+	buf.Reset()
+	NewHeaderless().WithWriter(&buf).WithPlainMode(true).WithUniqueHeaders(true).
+		AddRow("a", "a").
+		PromoteFirstRowToHeader().
+		Print()
+	assert.Equal(t, "a\ta_2\n", buf.String())
+
+	// disabled by default
+	buf.Reset()
+	New("Name", "Name").WithWriter(&buf).WithPlainMode(true).Print()
+	assert.Equal(t, "Name\tName\n", buf.String())
+}
+
+func TestTable_WithLastColumnFlushRight(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("Setting", "Value").
+		WithWriter(&buf).
+		WithTotalWidth(24).
+		WithLastColumnFlushRight(true).
+		AddRow("timeout", "30s").
+		AddRow("retries", 3)
+
+	tbl.Print()
+	expected := `Setting          Value  
+timeout            30s  
+retries              3  
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// the panel borders count towards the total width
+	buf.Reset()
+	tbl.WithPanel("cfg").Print()
+	expected = `┌─ cfg ────────────────┐
+│ Setting       Value  │
+│ timeout         30s  │
+│ retries           3  │
+└──────────────────────┘
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// terminals are filled to their width
+	term := terminalBuffer{}
+	tbl.WithPanel("").WithTotalWidth(0).WithWriter(&term).Print()
+	assert.Equal(t, "Setting          Value  \ntimeout            30s  \nretries              3  \n", term.String())
+
+	// without a known width, the last column is only right-aligned
+	buf.Reset()
+	tbl.WithWriter(&buf).Print()
+	expected = `Setting  Value  
+timeout    30s  
+retries      3  
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+}
+
+func TestTable_Normalize(t *testing.T) {
+	t.Parallel()
+
+	tbl := New("ID", "Name").AddSeparatorRow()
+
+	// rows are padded as they are added, so make them ragged directly
+	raw := tbl.(*table)
+	raw.rows = append(raw.rows, []string{"1"}, []string{"2", "foo", "extra"}, []string{})
+
+	tbl.Normalize()
+	assert.Equal(t, [][]string{nil, {"1", ""}, {"2", "foo"}, {"", ""}}, raw.rows)
+
+	buf := bytes.Buffer{}
+	tbl.WithWriter(&buf).WithPlainMode(true).Print()
+	assert.Equal(t, "ID\tName\n1\t\n2\tfoo\n\t\n", buf.String())
+}
+
+func TestTable_AddSeparatorRow(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("Item", "Cost").
+		WithWriter(&buf).
+		WithLineNumbers(true).
+		AddRow("pen", 1).
+		AddRow("ink", 3).
+		AddSeparatorRow().
+		AddRow("total", 4)
+
+	tbl.Print()
+	expected := `#  Item   Cost  
+1  pen    1     
+2  ink    3     
+----------------
+3  total  4     
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// the header separator rune is used if set
+	buf.Reset()
+	tbl.WithLineNumbers(false).WithHeaderSeparatorRow('=').Print()
+	assert.Contains(t, buf.String(), "ink    3     \n=============\ntotal")
+
+	// plain mode and exports skip the rule
+	buf.Reset()
+	tbl.WithPlainMode(true).Print()
+	assert.Equal(t, "Item\tCost\npen\t1\nink\t3\ntotal\t4\n", buf.String())
+
+	buf.Reset()
+	assert.NoError(t, tbl.ExportOrg(&buf))
+	assert.Equal(t, 5, strings.Count(buf.String(), "\n"))
+	assert.Len(t, tbl.TemplateData().Rows, 3)
+}
+
+// terminalBuffer is a bytes.Buffer that reports itself as a terminal.
+type terminalBuffer struct {
+	bytes.Buffer
+}
+
+// terminalFd is the file descriptor of every terminalBuffer, which is never
+// that of an open file, and terminalWidth is the width of its terminal.
+const (
+	terminalFd    = 1 << 30
+	terminalWidth = 24
+)
+
+func (*terminalBuffer) Fd() uintptr { return uintptr(terminalFd) }
+
+func init() {
+	isTerminal := isTerminalFd
+	isTerminalFd = func(fd int) bool {
+		return fd == terminalFd || isTerminal(fd)
+	}
+
+	size := terminalSize
+	terminalSize = func(fd int) (int, int, error) {
+		if fd == terminalFd {
+			return terminalWidth, 25, nil
+		}
+		return size(fd)
+	}
+}
+
+func TestTable_WithBoolSymbols(t *testing.T) {
+	t.Parallel()
+
+	buf := terminalBuffer{}
+	tbl := New("Name", "Active").
+		WithWriter(&buf).
+		AddRow("alice", true).
+		AddRow("bob", "F")
+
+	// disabled by default
+	assert.True(t, tbl.IsTerminal())
+	tbl.Print()
+	assert.Equal(t, "Name   Active  \nalice  true    \nbob    F       \n", buf.String())
+
+	buf.Reset()
+	tbl.WithBoolSymbols(true).Print()
+	expected := "Name   Active  \n" +
+		"alice  \x1b[32m✓\x1b[0m       \n" +
+		"bob    \x1b[31m✗\x1b[0m       \n"
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// opting out prints the cells as they are
+	buf.Reset()
+	tbl.WithBoolSymbols(false).Print()
+	assert.Equal(t, "Name   Active  \nalice  true    \nbob    F       \n", buf.String())
+
+	// symbols are only used on a terminal
+	plain := bytes.Buffer{}
+	tbl.WithBoolSymbols(true).WithWriter(&plain).Print()
+	assert.NotContains(t, plain.String(), "✓")
+}
+
+func TestTable_PrintFirstColumns(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("A", "B", "C", "D").
+		WithWriter(&buf).
+		WithZeroPad(2, 3).
+		AddRow(1, 2, 3, 4).
+		AddRow(5, 6)
+
+	tbl.PrintFirstColumns(2)
+	expected := `A  B  (+2)  
+1  2        
+5  6        
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// running totals count as omitted columns
+	buf.Reset()
+	tbl.WithRunningTotal(0, "Sum").PrintFirstColumns(4)
+	assert.Equal(t, "A  B  C    D  (+1)  \n1  2  003  4        \n5  6                \n", buf.String())
+
+	// enough columns prints the whole table
+	buf.Reset()
+	tbl.PrintFirstColumns(5)
+	assert.Contains(t, buf.String(), "Sum")
+	assert.Contains(t, buf.String(), "003")
+
+	// abbreviations of hidden columns leave the indicator column empty
+	buf.Reset()
+	New("A", "B").WithWriter(&buf).
+		WithColumnAbbreviations(1, map[string]string{"": "n/a"}).
+		AddRow(1, "").
+		PrintFirstColumns(1)
+	assert.Equal(t, "A  (+1)  \n1        \n", buf.String())
+
+	// thresholds are read from hidden columns
+	buf.Reset()
+	mark := func(f string, v ...interface{}) string { return "*" + fmt.Sprintf(f, v...) }
+	New("Host", "Errors").WithWriter(&buf).
+		WithThresholdColoring(1, []Threshold{{Value: 10, Formatter: mark}}).
+		AddRow("a", 1).
+		AddRowAligned([]Alignment{AlignLeft, AlignRight}, "b", 42).
+		PrintFirstColumns(1)
+	assert.Equal(t, "Host  (+1)  \na           \n*b     *      \n", buf.String())
+}
+
+func TestNewHeaderless(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := NewHeaderless().
+		WithWriter(&buf).
+		WithHeaderSeparatorRow('-').
+		AddRow("name", "age").
+		AddRow("alice", 30).
+		AddRow("bob", 4, "extra")
+
+	tbl.Print()
+	expected := `name   age         
+alice  30          
+bob    4    extra  
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	buf.Reset()
+	tbl.PromoteFirstRowToHeader().Print()
+	expected = `name   age         
+----   ---         
+alice  30          
+bob    4    extra  
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+	assert.Equal(t, "age", tbl.Schema()[1].Header)
+
+	// no rows leaves the table unchanged
+	buf.Reset()
+	NewHeaderless().WithWriter(&buf).WithPlainMode(true).PromoteFirstRowToHeader().Print()
+	assert.Empty(t, buf.String())
+}
+
+func TestTable_WithColumnAbbreviations(t *testing.T) {
+	t.Parallel()
+
+	abbr := map[string]string{"United States": "US", "United Kingdom": "UK"}
+
+	buf := bytes.Buffer{}
+	tbl := New("City", "Country").
+		WithWriter(&buf).
+		WithColumnAbbreviations(1, abbr).
+		AddRow("Boston", "United States").
+		AddRow("Leeds", "United Kingdom").
+		AddRow("Paris", "France")
+
+	// later changes to the map are not picked up
+	abbr["France"] = "FR"
+
+	tbl.Print()
+	expected := `City    Country  
+Boston  US       
+Leeds   UK       
+Paris   France   
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// an empty map removes the abbreviations
+	buf.Reset()
+	tbl.WithColumnAbbreviations(1, nil).Print()
+	assert.Contains(t, buf.String(), "United States")
+}
+
+func TestTable_WithPanel(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("ID", "Name").
+		WithWriter(&buf).
+		WithPanel("Users").
+		WithHeaderSeparatorRow('-').
+		AddRow(1, "foo").
+		AddRow(2, "barbaz")
+
+	tbl.Print()
+	expected := `┌─ Users ─────┐
+│ ID  Name    │
+│ --  ----    │
+│ 1   foo     │
+│ 2   barbaz  │
+└─────────────┘
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// a long title widens the panel
+	buf.Reset()
+	tbl.WithPanel("Registered users").WithLineNumbers(true).Print()
+	expected = `┌─ Registered users ─┐
+│ #  ID  Name        │
+│ -  --  ----        │
+│ 1  1   foo         │
+│ 2  2   barbaz      │
+└────────────────────┘
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// plain mode has no panel
+	buf.Reset()
+	tbl.WithPlainMode(true).Print()
+	assert.NotContains(t, buf.String(), "┌")
+}
+
+func TestTable_ClearPrevious(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("ID", "Name").
+		WithWriter(&buf).
+		WithHeaderSeparatorRow('-').
+		AddRow(1, "foo").
+		AddRow(2, "bar")
+
+	// nothing to clear before printing
+	tbl.ClearPrevious()
+	assert.Empty(t, buf.String())
+
+	tbl.Print()
+	buf.Reset()
+	tbl.ClearPrevious()
+	assert.Equal(t, "\x1b[4A\r\x1b[J", buf.String())
+
+	// clearing twice has no effect
+	buf.Reset()
+	tbl.ClearPrevious()
+	assert.Empty(t, buf.String())
+}
+
+func TestTable_WithUnicodeNormalization(t *testing.T) {
+	t.Parallel()
+
+	decomposed := "Zoe\u0308" // e followed by a combining diaeresis
+	composed := "Zo\u00eb"
+
+	buf := bytes.Buffer{}
+	tbl := New("Name", "City").
+		WithWriter(&buf).
+		WithUnicodeNormalization(norm.NFC).
+		AddRow(decomposed, "Malmö")
+
+	assert.True(t, tbl.Equal(New("Name", "City").AddRow(composed, "Malmö")))
+	tbl.Print()
+	assert.Equal(t, "Name  City   \nZoë   Malmö  \n", buf.String())
+
+	// SetRows is normalized too, without changing the caller's rows
+	rows := [][]string{{decomposed}}
+	tbl.SetRows(rows)
+	assert.Equal(t, decomposed, rows[0][0])
+	assert.True(t, tbl.Equal(New("Name", "City").AddRow(composed)))
+
+	// values are left alone by default
+	assert.False(t, New("Name").AddRow(decomposed).Equal(New("Name").AddRow(composed)))
+}
+
+func TestTable_WithRowTotalColumn(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("Item", "Q1", "Q2", "Note").
+		WithWriter(&buf).
+		WithRunningTotal(1, "Q1 YTD").
+		WithRowTotalColumn("Total", []int{1, 2}).
+		AddRow("pens", 3, 4.5, "a").
+		AddRow("ink", 2, "-", "b").
+		AddRow("pads", 1, 0.25)
+
+	tbl.Print()
+	expected := `Item  Q1  Q2    Note  Q1 YTD  Total  
+pens  3   4.5   a     3       7.5    
+ink   2   -     b     5       2      
+pads  1   0.25        6       1.25   
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// strict totals leave rows with non-numeric cells empty
+	buf.Reset()
+	tbl.WithRowTotalStrict(true).WithPlainMode(true).Print()
+	assert.Contains(t, buf.String(), "ink\t2\t-\tb\t5\t\n")
+
+	// negative columns are ignored
+	buf.Reset()
+	tbl.WithRowTotalColumn("Total", []int{-1, 1}).Print()
+	assert.Contains(t, buf.String(), "ink\t2\t-\tb\t5\t2\n")
+
+	// an empty header removes the column
+	buf.Reset()
+	tbl.WithRowTotalColumn("", nil).Print()
+	assert.NotContains(t, buf.String(), "Total")
+}
+
+func TestTable_WithColumnCase(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("Name", "Code", "Tag").
+		WithWriter(&buf).
+		WithColumnCase(0, CaseTitle).
+		WithColumnCase(1, CaseUpper).
+		WithColumnCase(2, CaseLower).
+		AddRow("ada LOVELACE", "gb-eng", "Math").
+		AddRow("o'neil de-la cruz", "us", "CS")
+
+	tbl.Print()
+	expected := `Name               Code    Tag   
+Ada Lovelace       GB-ENG  math  
+O'neil De-La Cruz  US      cs    
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// CaseNone removes the change
+	buf.Reset()
+	tbl.WithColumnCase(1, CaseNone).Print()
+	assert.Contains(t, buf.String(), "gb-eng")
+}
+
+func TestTable_PrintPage(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("ID", "Name").
+		WithWriter(&buf).
+		WithLineNumbers(true).
+		WithRunningTotal(0, "Sum").
+		AddRow(1, "a").
+		AddRow(2, "b").
+		AddRow(3, "a much longer name").
+		AddRow(4, "d").
+		AddRow(5, "e")
+
+	tbl.PrintPage(2, 2)
+	expected := `#  ID  Name                Sum  
+3  3   a much longer name  6    
+4  4   d                   10   
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// pages are clamped to the rows that exist
+	buf.Reset()
+	tbl.PrintPage(4, 10)
+	assert.Equal(t, 2, strings.Count(buf.String(), "\n"))
+
+	buf.Reset()
+	tbl.PrintPage(-5, 1)
+	assert.Contains(t, buf.String(), "1  1   a")
+
+	buf.Reset()
+	tbl.PrintPage(10, 2)
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+
+	assert.Equal(t, 3, tbl.PageCount(2))
+	assert.Equal(t, 1, tbl.PageCount(5))
+	assert.Equal(t, 0, tbl.PageCount(0))
+}
+
+func TestTable_ShortRows(t *testing.T) {
+	t.Parallel()
+
+	// rows added before the header grows are padded to the new width
+	buf := bytes.Buffer{}
+	tbl := NewHeaderless().
+		WithWriter(&buf).
+		AddRow("ID").
+		AddRow(1).
+		AddRow(2, "bob").
+		PromoteFirstRowToHeader().
+		WithNoTruncate(true).
+		AddRow(3, "carol", "admin")
+
+	tbl.Print()
+	expected := `ID                
+1                 
+2   bob           
+3   carol  admin  
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	for _, row := range tbl.TemplateData().Rows {
+		assert.Len(t, row, 3)
+	}
+
+	buf.Reset()
+	assert.NoError(t, tbl.ExportOrg(&buf))
+	assert.Contains(t, buf.String(), "| 1  |       |       |\n")
+
+	// SetRows pads short rows too
+	buf.Reset()
+	tbl = New("ID", "Name").WithWriter(&buf).SetRows([][]string{{"1"}})
+	assert.NoError(t, tbl.ExportJSONNested(nil))
+	assert.Equal(t, `[{"ID":"1","Name":""}]`+"\n", buf.String())
+}
+
+func TestTable_WithColumnRightBorder(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.Buffer{}
+	tbl := New("Account", "Debit", "Credit").
+		WithWriter(&buf).
+		WithHeaderSeparatorRow('-').
+		WithColumnRightBorder(0, '│').
+		WithColumnRightBorder(1, '%').
+		WithColumnGroupBoundaries([]int{1}, "! ").
+		AddRow("cash", 100, "").
+		AddRow("sales", "", 100)
+
+	tbl.Print()
+	expected := `Account  │ Debit  % ! Credit  
+-------  │ -----  % ! ------  
+cash     │ 100    % !         
+sales    │        % ! 100     
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Fatalf("table mismatch (-expected +got):\n%s\nout=%#v", diff, buf.String())
+	}
+
+	// the panel accounts for the border
+	buf.Reset()
+	tbl.WithColumnRightBorder(1, 0).WithColumnGroupBoundaries(nil, "").WithPanel("Ledger").Print()
+	assert.Contains(t, buf.String(), "│ cash     │ 100            │\n")
+}
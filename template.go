@@ -0,0 +1,49 @@
+package table
+
+// TemplateData is a snapshot of a table's contents and computed layout,
+// shaped for use with text/template or html/template.
+//
+//	tmpl := template.Must(template.New("").Parse(
+//	  `{{range .Rows}}{{index . 0}}: {{index . 1}}{{"\n"}}{{end}}`))
+//	tmpl.Execute(os.Stdout, tbl.TemplateData())
+type TemplateData struct {
+	// Headers holds the column headers as they would be printed.
+	Headers []string
+
+	// Rows holds the cells of each row as they would be printed, with any
+	// column options applied. Every row has exactly len(Headers) cells.
+	Rows [][]string
+
+	// Widths holds the width of each column's widest cell, excluding padding.
+	Widths []int
+
+	// Padding is the number of spaces the table places between columns.
+	Padding int
+}
+
+func (t *table) TemplateData() TemplateData {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	rows := t.displayRows()
+	t.measureWidths(rows)
+
+	data := TemplateData{
+		Headers: t.displayHeader(),
+		Rows:    make([][]string, 0, len(rows)),
+		Widths:  make([]int, len(t.widths)),
+		Padding: t.Padding,
+	}
+
+	for _, row := range rows {
+		if row != nil {
+			data.Rows = append(data.Rows, row)
+		}
+	}
+
+	for i, w := range t.widths {
+		data.Widths[i] = w - t.Padding
+	}
+
+	return data
+}
@@ -0,0 +1,42 @@
+package table
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable_TemplateData(t *testing.T) {
+	t.Parallel()
+
+	tbl := New("ID", "Name").
+		WithPadding(3).
+		WithZeroPad(0, 2).
+		AddRow(1, "foo").
+		AddRow(2)
+
+	data := tbl.TemplateData()
+	assert.Equal(t, TemplateData{
+		Headers: []string{"ID", "Name"},
+		Rows:    [][]string{{"01", "foo"}, {"02", ""}},
+		Widths:  []int{2, 4},
+		Padding: 3,
+	}, data)
+
+	tmpl := template.Must(template.New("").Parse(
+		`{{range .Rows}}{{index . 0}}={{index . 1}};{{end}}`))
+
+	buf := bytes.Buffer{}
+	assert.NoError(t, tmpl.Execute(&buf, data))
+	assert.Equal(t, "01=foo;02=;", buf.String())
+
+	// mutating the data does not affect the table
+	data.Rows[0][1] = "bar"
+	assert.Equal(t, "foo", tbl.TemplateData().Rows[0][1])
+
+	// the footer counts towards the widths
+	tbl.AddFooter("grand total")
+	assert.Equal(t, []int{11, 4}, tbl.TemplateData().Widths)
+}